@@ -0,0 +1,114 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Alias declares oldKey as an alternative name of the already declared newKey, e.g. after a key has been renamed.
+// Afterward, every key based access using the old key, like [Bundle.Resolve], [Bundle.Update] or the importers,
+// transparently uses the handle of the new key. This includes the stable tokens of the old key,
+// see [StrHnd.StableString]. Messages returned or persisted use the new key. Returns
+// [os.ErrExist] if the old key is already in use.
+func (r *Resources) Alias(oldKey, newKey Key) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.reverseHandles.Get(oldKey); ok {
+		return fmt.Errorf("cannot alias %v: %w", oldKey, os.ErrExist)
+	}
+
+	hnd, ok := r.reverseHandles.Get(newKey)
+	if !ok {
+		return fmt.Errorf("key has no associated string handle: %v", newKey)
+	}
+
+	// always point to the declared key, even if newKey is an alias itself
+	canonical, _ := r.handles.Get(hnd)
+
+	r.reverseHandles.Put(oldKey, hnd)
+	r.aliases.Put(oldKey, canonical)
+	r.registerStable(oldKey, hnd, true)
+	r.mutated()
+	return nil
+}
+
+// RemoveAlias removes an alias which has been declared by [Resources.Alias], so that the old key and its stable
+// tokens become unknown again. Returns [os.ErrNotExist] if the given key is not an alias.
+func (r *Resources) RemoveAlias(alias Key) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.aliases.Get(alias); !ok {
+		return fmt.Errorf("cannot remove alias %v: %w", alias, os.ErrNotExist)
+	}
+
+	hnd, _ := r.reverseHandles.Get(alias)
+	if v, ok := r.stableHandles.Get(keyHash(alias)); ok && v == hnd {
+		r.stableHandles.Delete(keyHash(alias))
+	}
+
+	r.reverseHandles.Delete(alias)
+	r.aliases.Delete(alias)
+	r.mutated()
+	return nil
+}
+
+// Aliases returns all declared aliases and the keys they point to in ascending order of the aliases.
+func (r *Resources) Aliases() iter.Seq2[Key, Key] {
+	var tmp []Key
+	for alias := range r.aliases.All() {
+		tmp = append(tmp, alias)
+	}
+
+	slices.Sort(tmp)
+
+	return func(yield func(Key, Key) bool) {
+		for _, alias := range tmp {
+			key, _ := r.aliases.Get(alias)
+			if !yield(alias, key) {
+				return
+			}
+		}
+	}
+}
+
+// AliasesOf returns the aliases of the given key in ascending order.
+func (r *Resources) AliasesOf(key Key) []Key {
+	var res []Key
+	for alias, target := range r.Aliases() {
+		if target == key {
+			res = append(res, alias)
+		}
+	}
+
+	return res
+}
+
+// canonicalKey returns the declared key for the given alias or the key itself.
+func (r *Resources) canonicalKey(key Key) Key {
+	if target, ok := r.aliases.Get(key); ok {
+		return target
+	}
+
+	return key
+}
+
+func formatAliases(aliases []Key) string {
+	tmp := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		tmp = append(tmp, string(alias))
+	}
+
+	return strings.Join(tmp, " ")
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_Alias(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("screen.greeting.hello", i18n.Values{language.English: "hello"}))
+
+	if err := res.Alias("login.hello", "screen.greeting.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.Alias("login.hello", "screen.greeting.hello"); err == nil {
+		t.Fatal("expected duplicate alias error")
+	}
+
+	if err := res.Alias("x", "unknown"); err == nil {
+		t.Fatal("expected unknown key error")
+	}
+
+	store := i18n.NewMemoryStore()
+	if err := res.Attach(store); err != nil {
+		t.Fatal(err)
+	}
+
+	en := res.MustMatchBundle(language.English)
+	if v := en.Resolve("login.hello"); v != "hello" {
+		t.Fatal(v)
+	}
+
+	if err := en.Update(i18n.Message{Key: "login.hello", Value: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(en); v != "hi" {
+		t.Fatal(v)
+	}
+
+	if msg := option.Must(store.Load(language.English, "screen.greeting.hello")); msg.Value != "hi" {
+		t.Fatal("expected canonical key in store", msg)
+	}
+
+	if keys := res.SortedKeys(); len(keys) != 1 {
+		t.Fatal("aliases must not be listed as keys", keys)
+	}
+
+	var buf bytes.Buffer
+	if err := res.ExportCSV(&buf, i18n.CSVOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "screen.greeting.hello,,,,,login.hello,hi") {
+		t.Fatal(buf.String())
+	}
+
+	var restored i18n.Resources
+	if err := restored.UnmarshalBinary(option.Must(res.MarshalBinary())); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := restored.MustMatchBundle(language.English).Resolve("login.hello"); v != "hi" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_RemoveAlias(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("screen.greeting.hello", i18n.Values{language.English: "hello"}))
+	if err := res.Alias("login.hello", "screen.greeting.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.RemoveAlias("login.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.RemoveAlias("screen.greeting.hello"); err == nil {
+		t.Fatal("a declared key is not an alias")
+	}
+
+	res.Flush()
+	en := res.MustMatchBundle(language.English)
+	if v := en.Resolve("login.hello"); v != "login.hello" {
+		t.Fatal(v)
+	}
+
+	if v := en.Resolve(hello.StableString()); v != "hello" {
+		t.Fatal(v)
+	}
+
+	if v := res.AliasesOf("screen.greeting.hello"); len(v) != 0 {
+		t.Fatal(v)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"sync"
+	"time"
+)
+
+// AutoFlushOptions configures the automatic flush management, see [Resources.SetAutoFlush].
+type AutoFlushOptions struct {
+	// Delay flushes after the given quiet period without further mutations. Zero disables the delayed flush.
+	Delay time.Duration
+
+	// Mutations flushes after the given amount of unflushed mutations. Zero disables the counting flush.
+	Mutations int
+}
+
+// Stats describes the internal buffer state of a [Resources] instance, see [Resources.Stats].
+type Stats struct {
+	// Dirty is true, if there are unflushed mutations and reads take the slower path under a mutex.
+	Dirty bool
+
+	// Mutations is the number of mutations since the last flush.
+	Mutations int
+
+	// SlowReads counts all reads which had to take the slower path under a mutex because of unflushed
+	// mutations. A steadily growing number indicates a missing [Resources.Flush].
+	SlowReads uint64
+
+	// Flushes counts how often [Resources.Flush] has been called.
+	Flushes uint64
+
+	// LastFlush is the time of the last flush or the zero time.
+	LastFlush time.Time
+}
+
+type flushState struct {
+	mutex     sync.Mutex
+	opts      AutoFlushOptions
+	timer     *time.Timer
+	mutations int
+	due       bool
+	flushes   uint64
+	lastFlush time.Time
+}
+
+// SetAutoFlush enables the automatic flush management. Each mutation, like adding a key or [Bundle.Update],
+// is counted and the instance is flushed either after the configured amount of mutations or after the
+// configured quiet period. Mutations of overlays are not managed. The zero value disables the automatic flush,
+// which is the default.
+func (r *Resources) SetAutoFlush(opts AutoFlushOptions) {
+	r.flushes.mutex.Lock()
+	defer r.flushes.mutex.Unlock()
+
+	r.flushes.opts = opts
+	if r.flushes.timer != nil && opts.Delay == 0 {
+		r.flushes.timer.Stop()
+		r.flushes.timer = nil
+	}
+}
+
+// mutated accounts a mutation for the automatic flush and invalidates the resolved tables. It may be called
+// while holding the mutex.
+func (r *Resources) mutated() {
+	r.generation.Add(1)
+
+	r.flushes.mutex.Lock()
+	defer r.flushes.mutex.Unlock()
+
+	r.flushes.mutations++
+	opts := r.flushes.opts
+	if opts.Mutations > 0 && r.flushes.mutations >= opts.Mutations {
+		r.flushes.due = true
+	}
+
+	if opts.Delay > 0 {
+		if r.flushes.timer == nil {
+			r.flushes.timer = time.AfterFunc(opts.Delay, r.Flush)
+		} else {
+			r.flushes.timer.Reset(opts.Delay)
+		}
+	}
+}
+
+// flushDue returns true if the mutation limit has been reached. See [AutoFlushOptions.Mutations].
+func (r *Resources) flushDue() bool {
+	r.flushes.mutex.Lock()
+	defer r.flushes.mutex.Unlock()
+
+	return r.flushes.due
+}
+
+func (r *Resources) flushed() {
+	r.flushes.mutex.Lock()
+	defer r.flushes.mutex.Unlock()
+
+	r.flushes.mutations = 0
+	r.flushes.due = false
+	r.flushes.flushes++
+	r.flushes.lastFlush = time.Now()
+	if r.flushes.timer != nil {
+		r.flushes.timer.Stop()
+	}
+}
+
+// Stats returns the current buffer state and counters, e.g. to detect a missing [Resources.Flush] in production.
+func (r *Resources) Stats() Stats {
+	r.flushes.mutex.Lock()
+	stats := Stats{
+		Mutations: r.flushes.mutations,
+		Flushes:   r.flushes.flushes,
+		LastFlush: r.flushes.lastFlush,
+	}
+	r.flushes.mutex.Unlock()
+
+	for _, b := range r.buffers() {
+		stats.Dirty = stats.Dirty || b.isDirty()
+		stats.SlowReads += b.slowReadCount()
+	}
+
+	for _, bnd := range r.children.All() {
+		stats.Dirty = stats.Dirty || bnd.strings.isDirty() || bnd.drafts.isDirty()
+		stats.SlowReads += bnd.strings.slowReadCount() + bnd.drafts.slowReadCount()
+	}
+
+	return stats
+}
+
+// buffer is the common interface of [bufferedSlice] and [bufferedMap].
+type buffer interface {
+	Flush()
+	isDirty() bool
+	slowReadCount() uint64
+}
+
+// buffers returns all buffers of the instance itself, but not of its bundles.
+func (r *Resources) buffers() []buffer {
+	return []buffer{
+		&r.children,
+		&r.handles,
+		&r.reverseHandles,
+		&r.keyDescriptions,
+		&r.kinds,
+		&r.deprecations,
+		&r.aliases,
+		&r.stableHandles,
+		&r.namespaces,
+		&r.priorities,
+		&r.fallbacks,
+	}
+}
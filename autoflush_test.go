@@ -0,0 +1,81 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_Stats(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	en := res.MustMatchBundle(language.English)
+	hello.Get(en)
+
+	stats := res.Stats()
+	if !stats.Dirty || stats.SlowReads == 0 || stats.Flushes != 0 || !stats.LastFlush.IsZero() {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	res.Flush()
+	slowReads := res.Stats().SlowReads
+	hello.Get(en)
+
+	stats = res.Stats()
+	if stats.Dirty || stats.SlowReads != slowReads || stats.Flushes != 1 || stats.LastFlush.IsZero() {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestResources_SetAutoFlush(t *testing.T) {
+	var res i18n.Resources
+	res.SetAutoFlush(i18n.AutoFlushOptions{Mutations: 3})
+
+	option.Must(res.AddString("app.a", i18n.Values{language.English: "a"}))
+	if stats := res.Stats(); !stats.Dirty || stats.Mutations != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	option.Must(res.AddString("app.b", i18n.Values{language.English: "b"}))
+	if stats := res.Stats(); stats.Dirty || stats.Flushes != 1 {
+		t.Fatalf("expected flush: %+v", stats)
+	}
+
+	res.SetAutoFlush(i18n.AutoFlushOptions{Delay: time.Millisecond})
+	option.Must(res.AddString("app.c", i18n.Values{language.English: "c"}))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for res.Stats().Dirty {
+		if time.Now().After(deadline) {
+			t.Fatal("expected delayed flush")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestResources_AutoFlushConcurrent(t *testing.T) {
+	var res i18n.Resources
+	res.SetAutoFlush(i18n.AutoFlushOptions{Delay: time.Microsecond})
+	en, _ := res.AddLanguage(language.English)
+
+	// the timer flushes concurrently to the mutations, which must never publish a table without the new message
+	for i := range 2000 {
+		value := strconv.Itoa(i)
+		hnd := option.Must(res.AddString(i18n.Key("app."+value), i18n.Values{language.English: value}))
+		if v := hnd.Get(en); v != value {
+			t.Fatalf("expected %s but got %s", value, v)
+		}
+	}
+}
@@ -0,0 +1,524 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strings"
+
+	"github.com/worldiety/i18n/parser"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+var binaryMagic = []byte("I18N")
+
+// binaryVersion must be incremented whenever the snapshot layout changes.
+const binaryVersion = 6
+
+// MarshalBinary creates a compact snapshot of all handles, keys, hints, priorities, fallbacks and bundles including
+// the pre-tokenized templates. The snapshot is versioned and protected by a CRC32 checksum.
+// See also [Resources.UnmarshalBinary].
+func (r *Resources) MarshalBinary() ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var w binWriter
+	w.buf = append(w.buf, binaryMagic...)
+	w.uvarint(binaryVersion)
+	w.uvarint(uint64(r.lastHandle.Load()))
+
+	w.uvarint(uint64(r.priorities.Len()))
+	for _, tag := range r.priorities.All() {
+		w.string(tag.String())
+	}
+
+	var fallbacks []language.Tag
+	for tag := range r.fallbacks.All() {
+		fallbacks = append(fallbacks, tag)
+	}
+
+	slices.SortFunc(fallbacks, func(a, b language.Tag) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	w.uvarint(uint64(len(fallbacks)))
+	for _, tag := range fallbacks {
+		chain, _ := r.fallbacks.Get(tag)
+		w.string(tag.String())
+		w.uvarint(uint64(len(chain)))
+		for _, fallback := range chain {
+			w.string(fallback.String())
+		}
+	}
+
+	type entry struct {
+		hnd int32
+		key Key
+	}
+
+	var entries []entry
+	for hnd, key := range r.handles.All() {
+		entries = append(entries, entry{hnd: hnd, key: key})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return int(a.hnd - b.hnd)
+	})
+
+	w.uvarint(uint64(len(entries)))
+	for _, e := range entries {
+		w.uvarint(uint64(slot(e.hnd)))
+		w.string(string(e.key))
+		kind, _ := r.kinds.Get(e.key)
+		w.buf = append(w.buf, byte(kind))
+		if replacement, ok := r.deprecations.Get(e.key); ok {
+			w.buf = append(w.buf, 1)
+			w.string(string(replacement))
+		} else {
+			w.buf = append(w.buf, 0)
+		}
+
+		hint, _ := r.keyDescriptions.Get(e.key)
+		w.string(hint)
+		w.uvarint(uint64(len(r.varHints[e.key])))
+		for _, vh := range r.varHints[e.key] {
+			w.string(vh.Name)
+			w.string(vh.Description)
+		}
+	}
+
+	var aliases []Key
+	for alias := range r.aliases.All() {
+		aliases = append(aliases, alias)
+	}
+
+	slices.Sort(aliases)
+	w.uvarint(uint64(len(aliases)))
+	for _, alias := range aliases {
+		target, _ := r.aliases.Get(alias)
+		w.string(string(alias))
+		w.string(string(target))
+	}
+
+	tags := r.Tags()
+	w.uvarint(uint64(len(tags)))
+	for _, tag := range tags {
+		bnd, _ := r.children.Get(tag)
+		w.string(tag.String())
+		if base := bnd.Inherits(); base != nil {
+			w.string(base.tag.String())
+		} else {
+			w.string("")
+		}
+
+		var count uint64
+		for _, data := range bnd.strings.All() {
+			if data.kind != MessageUndefined {
+				count++
+			}
+		}
+
+		w.uvarint(count)
+		for s, data := range bnd.strings.All() {
+			if data.kind == MessageUndefined {
+				continue
+			}
+
+			w.uvarint(uint64(s))
+			w.buf = append(w.buf, byte(data.kind), byte(data.status))
+			switch data.kind {
+			case MessageString:
+				w.string(data.constStr)
+			case MessageVarString:
+				w.template(data.template)
+			case MessageQuantities:
+				for _, tpl := range data.quantityTemplates.templates {
+					w.template(tpl)
+				}
+			}
+		}
+	}
+
+	w.buf = binary.BigEndian.AppendUint32(w.buf, crc32.ChecksumIEEE(w.buf))
+	return w.buf, nil
+}
+
+// UnmarshalBinary restores a snapshot created by [Resources.MarshalBinary] without parsing any template again.
+// The original handle slots are preserved, so that already persisted @1234 strings stay valid, if the snapshot
+// is restored into the same instance slot, e.g. from [Default] into [Default] of another process. It is allowed
+// to unmarshal into an instance which already contains keys, e.g. from package initialization, as long as
+// the keys and handles are identical. Contained messages replace the current ones and the instance is flushed
+// afterward. If the snapshot is corrupt or does not fit, the instance is not modified.
+func (r *Resources) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+4 || !bytes.HasPrefix(data, binaryMagic) {
+		return fmt.Errorf("not an i18n snapshot")
+	}
+
+	payload := data[:len(data)-4]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+		return fmt.Errorf("i18n snapshot checksum mismatch")
+	}
+
+	rd := binReader{buf: payload[len(binaryMagic):]}
+	if v := rd.uvarint(); v != binaryVersion {
+		return fmt.Errorf("unsupported i18n snapshot version %d", v)
+	}
+
+	lastHandle := int32(rd.slot())
+
+	priorities := make([]language.Tag, rd.count())
+	for i := range priorities {
+		priorities[i] = rd.tag()
+	}
+
+	type fallbackEntry struct {
+		tag   language.Tag
+		chain []language.Tag
+	}
+
+	fallbacks := make([]fallbackEntry, rd.count())
+	for i := range fallbacks {
+		fallbacks[i].tag = rd.tag()
+		fallbacks[i].chain = make([]language.Tag, rd.count())
+		for j := range fallbacks[i].chain {
+			fallbacks[i].chain[j] = rd.tag()
+		}
+	}
+
+	type keyEntry struct {
+		hnd         int32
+		key         Key
+		kind        MessageType
+		deprecated  bool
+		replacement Key
+		hint        string
+		varHints    []VarHint
+	}
+
+	keys := make([]keyEntry, rd.count())
+	for i := range keys {
+		keys[i].hnd = int32(rd.slot())
+		if keys[i].hnd == 0 {
+			rd.fail(fmt.Errorf("invalid handle slot %d", keys[i].hnd))
+		}
+		keys[i].key = Key(rd.string())
+		keys[i].kind = MessageType(rd.byte())
+		if rd.byte() == 1 {
+			keys[i].deprecated = true
+			keys[i].replacement = Key(rd.string())
+		}
+
+		keys[i].hint = rd.string()
+		if n := rd.count(); n > 0 {
+			keys[i].varHints = make([]VarHint, n)
+			for j := range keys[i].varHints {
+				keys[i].varHints[j] = VarHint{Name: rd.string(), Description: rd.string()}
+			}
+		}
+	}
+
+	aliases := make([][2]Key, rd.count())
+	for i := range aliases {
+		aliases[i] = [2]Key{Key(rd.string()), Key(rd.string())}
+	}
+
+	type bundleEntry struct {
+		slot int
+		data strData
+	}
+
+	type bundleSnapshot struct {
+		tag      language.Tag
+		inherits string
+		entries  []bundleEntry
+	}
+
+	bundles := make([]bundleSnapshot, rd.count())
+	for i := range bundles {
+		bundles[i].tag = rd.tag()
+		bundles[i].inherits = rd.string()
+		bundles[i].entries = make([]bundleEntry, rd.count())
+		for j := range bundles[i].entries {
+			e := &bundles[i].entries[j]
+			e.slot = rd.slot()
+			e.data.kind = MessageType(rd.byte())
+			e.data.status = MessageStatus(rd.byte())
+			switch e.data.kind {
+			case MessageString:
+				e.data.constStr = rd.string()
+			case MessageVarString:
+				e.data.template = rd.template()
+			case MessageQuantities:
+				for k := range e.data.quantityTemplates.templates {
+					e.data.quantityTemplates.templates[k] = rd.template()
+				}
+
+				e.data.quantityTemplates.raw = quantitiesOf(e.data.quantityTemplates.templates)
+			default:
+				rd.fail(fmt.Errorf("invalid message type %d", e.data.kind))
+			}
+		}
+	}
+
+	for _, snapshot := range bundles {
+		if snapshot.inherits != "" && !slices.ContainsFunc(bundles, func(b bundleSnapshot) bool {
+			return b.tag.String() == snapshot.inherits
+		}) {
+			rd.fail(fmt.Errorf("region %v inherits from unknown language %s", snapshot.tag, snapshot.inherits))
+		}
+	}
+
+	if rd.err != nil {
+		return fmt.Errorf("cannot decode i18n snapshot: %w", rd.err)
+	}
+
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if len(keys) > 0 {
+		if _, err := r.registerInstance(); err != nil {
+			return fmt.Errorf("cannot restore i18n snapshot: %w", err)
+		}
+	}
+
+	// the snapshot contains only slots, which are owned by this instance now
+	for i := range keys {
+		keys[i].hnd = r.handle(int(keys[i].hnd))
+	}
+
+	// check handle consistency first, so that we either apply everything or nothing
+	for _, k := range keys {
+		if hnd, ok := r.reverseHandles.Get(k.key); ok && hnd != k.hnd {
+			return fmt.Errorf("key %v has handle %d but snapshot declares %d", k.key, hnd, k.hnd)
+		}
+
+		if key, ok := r.handles.Get(k.hnd); ok && key != k.key {
+			return fmt.Errorf("handle %d belongs to %v but snapshot declares %v", k.hnd, key, k.key)
+		}
+	}
+
+	for _, alias := range aliases {
+		if hnd, ok := r.reverseHandles.Get(alias[0]); ok {
+			if key, _ := r.handles.Get(hnd); key != alias[1] {
+				return fmt.Errorf("alias %v conflicts with existing key %v", alias[0], key)
+			}
+		}
+	}
+
+	for _, k := range keys {
+		r.handles.Put(k.hnd, k.key)
+		r.reverseHandles.Put(k.key, k.hnd)
+		strHndTable.Put(k.hnd, formatStrHnd(k.hnd))
+		r.registerStable(k.key, k.hnd, false)
+		if k.kind != MessageUndefined {
+			r.kinds.Put(k.key, k.kind)
+		}
+
+		if k.deprecated {
+			r.deprecations.Put(k.key, k.replacement)
+		}
+
+		if k.hint != "" {
+			r.keyDescriptions.Put(k.key, k.hint)
+		}
+
+		if len(k.varHints) > 0 {
+			if r.varHints == nil {
+				r.varHints = map[Key][]VarHint{}
+			}
+
+			r.varHints[k.key] = k.varHints
+		}
+	}
+
+	for _, alias := range aliases {
+		if hnd, ok := r.reverseHandles.Get(alias[1]); ok {
+			r.reverseHandles.Put(alias[0], hnd)
+			r.aliases.Put(alias[0], alias[1])
+			r.registerStable(alias[0], hnd, true)
+		}
+	}
+
+	if lastHandle > r.lastHandle.Load() {
+		r.lastHandle.Store(lastHandle)
+	}
+
+	var regions []*Bundle
+	for _, snapshot := range bundles {
+		bnd, ok := r.children.Get(snapshot.tag)
+		if !ok {
+			bnd = r.addBundle(snapshot.tag)
+			if snapshot.inherits != "" {
+				regions = append(regions, bnd)
+			}
+		}
+
+		for _, e := range snapshot.entries {
+			bnd.strings.Set(e.slot, e.data)
+		}
+	}
+
+	// existing bundles keep their inheritance, thus only new regions are linked
+	for _, bnd := range regions {
+		for _, snapshot := range bundles {
+			if snapshot.tag != bnd.tag {
+				continue
+			}
+
+			for _, other := range bundles {
+				if other.tag.String() == snapshot.inherits {
+					base, _ := r.children.Get(other.tag)
+					bnd.inherits.Store(base)
+				}
+			}
+		}
+	}
+
+	r.priorities.Replace(priorities)
+	for _, f := range fallbacks {
+		r.fallbacks.Put(f.tag, f.chain)
+	}
+
+	r.clearMatcher()
+	r.mutated()
+	r.flush()
+
+	return nil
+}
+
+// quantitiesOf reconstructs the raw quantities from the given templates.
+func quantitiesOf(templates [plural.Many + 1]Template) Quantities {
+	return Quantities{
+		Zero:  templates[plural.Zero].raw,
+		One:   templates[plural.One].raw,
+		Two:   templates[plural.Two].raw,
+		Few:   templates[plural.Few].raw,
+		Many:  templates[plural.Many].raw,
+		Other: templates[plural.Other].raw,
+	}
+}
+
+type binWriter struct {
+	buf []byte
+}
+
+func (w *binWriter) uvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *binWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binWriter) template(tpl Template) {
+	w.string(tpl.raw)
+	w.uvarint(uint64(len(tpl.parts)))
+	for _, p := range tpl.parts {
+		w.buf = append(w.buf, byte(p.token.Type))
+		w.string(p.token.Value)
+	}
+}
+
+// binReader decodes the snapshot and remembers the first error, so that the decoding code can be
+// written linearly without checking each value.
+type binReader struct {
+	buf []byte
+	err error
+}
+
+func (r *binReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+
+	r.buf = nil
+}
+
+func (r *binReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail(errors.New("invalid varint"))
+		return 0
+	}
+
+	r.buf = r.buf[n:]
+	return v
+}
+
+// slot reads a handle slot and checks the range before the conversion, so that an overflowing value cannot
+// become negative.
+func (r *binReader) slot() int {
+	v := r.uvarint()
+	if v > hndSlotMask {
+		r.fail(fmt.Errorf("invalid handle slot %d", v))
+		return 0
+	}
+
+	return int(v)
+}
+
+// count reads a length prefix and protects against allocation bombs, because every element requires at
+// least a single byte.
+func (r *binReader) count() int {
+	v := r.uvarint()
+	if v > uint64(len(r.buf)) {
+		r.fail(errors.New("invalid length"))
+		return 0
+	}
+
+	return int(v)
+}
+
+func (r *binReader) byte() byte {
+	if len(r.buf) == 0 {
+		r.fail(errors.New("unexpected end of data"))
+		return 0
+	}
+
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *binReader) string() string {
+	n := r.count()
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+func (r *binReader) tag() language.Tag {
+	s := r.string()
+	tag, err := language.Parse(s)
+	if err != nil {
+		r.fail(err)
+	}
+
+	return tag
+}
+
+func (r *binReader) template() Template {
+	var tpl Template
+	tpl.raw = r.string()
+	if n := r.count(); n > 0 {
+		tpl.parts = make([]part, n)
+		for i := range tpl.parts {
+			tpl.parts[i].token.Type = parser.TokenType(r.byte())
+			tpl.parts[i].token.Value = r.string()
+		}
+	}
+
+	return tpl
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_MarshalBinary(t *testing.T) {
+	var res i18n.Resources
+	str := option.Must(res.AddString("app.str", i18n.Values{language.English: "hello", language.German: "hallo"}, i18n.LocalizationHint("a hint")))
+	varStr := option.Must(res.AddVarString("app.var", i18n.Values{language.English: "hello {name}"}, i18n.LocalizationVarHint("name", "the name")))
+	qStr := option.Must(res.AddQuantityString("app.q", i18n.QValues{language.English: {One: "one {name}", Other: "many {name}"}}))
+	res.Flush()
+
+	buf := option.Must(res.MarshalBinary())
+
+	var restored i18n.Resources
+	if err := restored.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// another instance owns other handles, thus look them up like another process would do at initialization
+	if str == restored.StringKey("app.str") {
+		t.Fatal("expected instance specific handle")
+	}
+
+	// handles must be preserved: the snapshot keeps the slots, so only the instance part of each handle differs
+	offset := int32(restored.StringKey("app.str")) - int32(str)
+	if int32(restored.StringKey("app.var"))-int32(varStr) != offset || int32(restored.StringKey("app.q"))-int32(qStr) != offset {
+		t.Fatal("expected preserved handle slots")
+	}
+
+	str = restored.StringKey("app.str")
+	varStr = i18n.VarStrHnd(restored.StringKey("app.var"))
+	qStr = i18n.QStrHnd(restored.StringKey("app.q"))
+
+	en := restored.MustMatchBundle(language.English)
+	if v := str.Get(restored.MustMatchBundle(language.German)); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	if v := varStr.Get(en, i18n.String("name", "x")); v != "hello x" {
+		t.Fatal(v)
+	}
+
+	if v := qStr.Get(en, 2, i18n.String("name", "x")); v != "many x" {
+		t.Fatal(v)
+	}
+
+	if v := en.Resolve(str.String()); v != "hello" {
+		t.Fatal(v)
+	}
+
+	if restored.Hint("app.str") != "a hint" {
+		t.Fatal("expected hint")
+	}
+
+	// new handles must not collide with the restored ones
+	next := option.Must(restored.AddString("app.next", i18n.Values{language.English: "next"}))
+	if int32(next) <= int32(qStr) {
+		t.Fatal("handle collision", next)
+	}
+
+	buf[len(buf)-5] ^= 0xff
+	if err := restored.UnmarshalBinary(buf); err == nil {
+		t.Fatal("expected checksum error")
+	}
+}
+
+func TestResources_UnmarshalBinaryConflict(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("a", i18n.Values{language.English: "a"}))
+	buf := option.Must(res.MarshalBinary())
+
+	var other i18n.Resources
+	option.Must(other.AddString("b", i18n.Values{language.English: "b"}))
+	if err := other.UnmarshalBinary(buf); err == nil {
+		t.Fatal("expected handle conflict")
+	}
+}
+
+func TestResources_UnmarshalBinaryInvalidSlot(t *testing.T) {
+	payload := []byte("I18N")
+	payload = binary.AppendUvarint(payload, 6) // version
+	payload = binary.AppendUvarint(payload, 1) // last handle
+	payload = append(payload, 0, 0, 0, 0)      // priorities, fallbacks, keys, aliases
+	payload = append(payload, 1, 2, 'e', 'n', 0, 1)
+	payload = binary.AppendUvarint(payload, 1<<63) // overflowing slot
+	payload = append(payload, byte(i18n.MessageString), 0, 1, 'x')
+	payload = binary.BigEndian.AppendUint32(payload, crc32.ChecksumIEEE(payload))
+
+	var res i18n.Resources
+	if err := res.UnmarshalBinary(payload); err == nil {
+		t.Fatal("expected invalid slot error")
+	}
+}
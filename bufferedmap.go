@@ -20,6 +20,7 @@ type bufferedMap[Key comparable, Value any] struct {
 	mutex   sync.RWMutex
 	readMap atomic.Pointer[map[Key]Value]
 	dirty   atomic.Bool
+	slow    atomic.Uint64 // counts the reads on the slow path
 }
 
 func (s *bufferedMap[Key, Value]) Get(key Key) (Value, bool) {
@@ -38,6 +39,7 @@ func (s *bufferedMap[Key, Value]) Get(key Key) (Value, bool) {
 	}
 
 	// slow path under mutex
+	s.slow.Add(1)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -73,6 +75,7 @@ func (s *bufferedMap[Key, Value]) Len() int {
 		return len(*slicePtr)
 	}
 
+	s.slow.Add(1)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 	return len(s.mutMap)
@@ -90,6 +93,23 @@ func (s *bufferedMap[Key, Value]) Put(key Key, val Value) {
 	s.dirty.Store(true)
 }
 
+func (s *bufferedMap[Key, Value]) Delete(key Key) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.mutMap, key)
+	s.dirty.Store(true)
+}
+
+// DeleteFunc removes all entries for which del returns true.
+func (s *bufferedMap[Key, Value]) DeleteFunc(del func(Key, Value) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	maps.DeleteFunc(s.mutMap, del)
+	s.dirty.Store(true)
+}
+
 func (s *bufferedMap[Key, Value]) All() iter.Seq2[Key, Value] {
 	return func(yield func(Key, Value) bool) {
 		if !s.dirty.Load() {
@@ -109,6 +129,7 @@ func (s *bufferedMap[Key, Value]) All() iter.Seq2[Key, Value] {
 		}
 
 		// slow path
+		s.slow.Add(1)
 		s.mutex.RLock()
 		defer s.mutex.RUnlock()
 		for k, v := range s.mutMap {
@@ -143,4 +164,13 @@ func (s *bufferedMap[Key, Value]) CopyInto(dst *bufferedMap[Key, Value]) {
 	}
 
 	dst.mutMap = tmp
+	dst.dirty.Store(true)
+}
+
+func (s *bufferedMap[Key, Value]) isDirty() bool {
+	return s.dirty.Load()
+}
+
+func (s *bufferedMap[Key, Value]) slowReadCount() uint64 {
+	return s.slow.Load()
 }
@@ -30,6 +30,8 @@ type bufferedSlice[T any] struct {
 	mutex     sync.RWMutex
 	readSlice atomic.Pointer[[]T]
 	dirty     atomic.Bool
+	slow      atomic.Uint64 // counts the reads on the slow path
+	version   atomic.Uint64 // counts the mutations
 }
 
 func (s *bufferedSlice[T]) At(idx int) (T, bool) {
@@ -50,6 +52,7 @@ func (s *bufferedSlice[T]) At(idx int) (T, bool) {
 	}
 
 	// slow path under mutex
+	s.slow.Add(1)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -89,6 +92,7 @@ func (s *bufferedSlice[T]) Len() int {
 		return len(*slicePtr)
 	}
 
+	s.slow.Add(1)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 	return len(s.mutSlice)
@@ -109,6 +113,7 @@ func (s *bufferedSlice[T]) Set(idx int, val T) {
 	}
 
 	s.mutSlice[idx] = val
+	s.version.Add(1)
 	s.dirty.Store(true)
 }
 
@@ -117,6 +122,7 @@ func (s *bufferedSlice[T]) Append(val ...T) {
 	defer s.mutex.Unlock()
 
 	s.mutSlice = append(s.mutSlice, val...)
+	s.version.Add(1)
 	s.dirty.Store(true)
 }
 
@@ -137,6 +143,7 @@ func (s *bufferedSlice[T]) Clear() {
 	// not sure what is better, the advantage is that we release the allocated memory
 	// otherwise we would need to iterate over to zero out and avoid leaking pointers
 	s.mutSlice = nil
+	s.version.Add(1)
 	s.dirty.Store(true)
 }
 
@@ -145,6 +152,7 @@ func (s *bufferedSlice[T]) Replace(slice []T) {
 	defer s.mutex.Unlock()
 
 	s.mutSlice = slices.Clone(slice)
+	s.version.Add(1)
 	s.dirty.Store(true)
 }
 
@@ -167,6 +175,7 @@ func (s *bufferedSlice[T]) All() iter.Seq2[int, T] {
 		}
 
 		// slow path
+		s.slow.Add(1)
 		s.mutex.RLock()
 		defer s.mutex.RUnlock()
 		for i, t := range s.mutSlice {
@@ -202,3 +211,46 @@ func (s *bufferedSlice[T]) Clone() *bufferedSlice[T] {
 	res.readSlice.Store(&tmp)
 	return res
 }
+
+// swapSlices updates all given slices in a single step, so that readers either observe the previous or the updated
+// contents of all slices but never a mix. While all slices are locked, readers are forced onto the blocking slow
+// path, the commit function is called, e.g. to invalidate derived data, and the updated contents are published
+// as already flushed read-only slices. The update function receives a private copy of the current contents.
+func swapSlices[T any](targets []*bufferedSlice[T], update func(i int, values []T) []T, commit func()) {
+	for _, s := range targets {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+	}
+
+	for _, s := range targets {
+		s.dirty.Store(true)
+	}
+
+	commit()
+
+	for i, s := range targets {
+		cur := s.mutSlice
+		if cur == nil {
+			if t := s.readSlice.Load(); t != nil {
+				cur = *t
+			}
+		}
+
+		s.mutSlice = update(i, slices.Clone(cur))
+		tmp := slices.Clone(s.mutSlice)
+		s.readSlice.Store(&tmp)
+		s.version.Add(1)
+	}
+
+	for _, s := range targets {
+		s.dirty.Store(false)
+	}
+}
+
+func (s *bufferedSlice[T]) isDirty() bool {
+	return s.dirty.Load()
+}
+
+func (s *bufferedSlice[T]) slowReadCount() uint64 {
+	return s.slow.Load()
+}
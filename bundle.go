@@ -13,6 +13,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/text/language"
 )
@@ -60,13 +63,21 @@ func (k Key) Directories() []string {
 // Bundle contains the localized resources with index accessors for all available resource types.
 // A bundle may be mutated by its owner [Resources]. A bundle may be used in high performance situations,
 // because all access patterns are done without locks or map hash calculations. Note, that only adding new values
-// and updating existing values are allowed. Removing is not supported and would fall into the category of
-// use-after-free errors. It is guaranteed that all Bundles of the same [Resources] parent, can address and
-// process the identical set of Handles.
+// and updating existing values are allowed. Removing a handle is not supported and would fall into the category of
+// use-after-free errors, however [Bundle.Delete] clears a value and keeps the handle slot valid. It is guaranteed
+// that all Bundles of the same [Resources] parent, can address and process the identical set of Handles.
 type Bundle struct {
 	parent  *Resources
 	tag     language.Tag
 	strings bufferedSlice[strData]
+	drafts  bufferedSlice[strData] // pending proposals, see [Bundle.Propose]
+	base    *Bundle                // non-nil for overlays, see [Bundle.Derive]
+	updates sync.Mutex             // serializes the store writes and revisions of Update and Delete
+
+	inherits  atomic.Pointer[Bundle]         // non-nil for regions, see [Resources.AddRegion]
+	inherited atomic.Pointer[inheritedTable] // precomputed by Flush
+	resolved  atomic.Pointer[resolvedTable]  // precomputed by Resources.Flush
+	hash      atomic.Pointer[contentHash]    // precomputed by Resources.Flush
 }
 
 func newBundle(parent *Resources, tag language.Tag) *Bundle {
@@ -78,6 +89,8 @@ func newBundle(parent *Resources, tag language.Tag) *Bundle {
 
 func (b *Bundle) Flush() {
 	b.strings.Flush()
+	b.drafts.Flush()
+	b.flushInherited()
 }
 
 func (b *Bundle) MustString(id StrHnd) string {
@@ -88,8 +101,9 @@ func (b *Bundle) MustString(id StrHnd) string {
 	panic(fmt.Errorf("cannot match string with tag %q", b.tag))
 }
 
-// Resolve tries to localize the given text. If a string starts with @ it tries to interpret it as @<handle>.
-// If you want to use the literal @<handle> escape it with a double @@. If no such handle is found, it falls through
+// Resolve tries to localize the given text. If a string starts with @ it tries to interpret it as @<handle> or as
+// a stable token @~<key hash> (see [StrHnd.StableString]). If you want to use the literal @<handle> escape it with
+// a double @@. If no such handle is found, it falls through
 // to the key-based translation. If this bundle does not contain anything, it tries to fallthrough other languages.
 // If that fails, it just returns the raw literal.
 func (b *Bundle) Resolve(text string, args ...Attr) string {
@@ -100,21 +114,29 @@ func (b *Bundle) Resolve(text string, args ...Attr) string {
 			return text[1:]
 		}
 
-		if hnd, err := strconv.Atoi(text[1:]); err == nil {
-			if v, ok := b.fuzzyMessage(hnd, args...); ok {
+		hnd, err := strconv.ParseInt(text[1:], 10, 32)
+		if h, ok := b.parent.stableHandle(text); ok {
+			hnd, err = int64(h), nil
+		}
+
+		if err == nil {
+			if v, ok := b.resolveHnd(int32(hnd), args...); ok {
 				return v
 			}
+
+			// the handle belongs to another living instance, thus ask that one for the same language
+			if !b.parent.owns(int32(hnd)) {
+				if owner, ok := lookupInstance(int32(hnd)); ok {
+					if bnd, ok := owner.MatchBundle(b.tag); ok {
+						return bnd.Resolve(text, args...)
+					}
+				}
+			}
 		}
 	}
 
 	if hnd, ok := b.parent.reverseHandles.Get(Key(text)); ok {
-		if v, ok := b.fuzzyMessage(int(hnd), args...); ok {
-			return v
-		}
-
-		// handle is valid but this bundle does not contain it
-		// slow O(n) fallback propagation through all prioritized bundles
-		if v, ok := b.parent.MatchString(b.tag, StrHnd(hnd)); ok {
+		if v, ok := b.resolveHnd(hnd, args...); ok {
 			return v
 		}
 	}
@@ -123,38 +145,74 @@ func (b *Bundle) Resolve(text string, args ...Attr) string {
 	return text
 }
 
-func (b *Bundle) fuzzyMessage(hnd int, args ...Attr) (string, bool) {
-	if data, ok := b.strings.At(hnd); ok {
-		if data.kind == MessageString {
-			return data.constStr, true
-		}
+// resolveHnd formats the message of the handle of any kind using the overlay base and the fallback chain.
+func (b *Bundle) resolveHnd(hnd int32, args ...Attr) (string, bool) {
+	if data, ok := b.resolvedAt(hnd); ok {
+		return b.execute(data, args...)
+	}
 
-		if data.kind == MessageVarString {
-			return data.template.Execute(args...), true
-		}
+	if v, ok := b.fuzzyMessage(hnd, args...); ok {
+		return v, true
+	}
 
-		var quantity float64
-		if data.kind == MessageQuantities {
-			for _, arg := range args {
-				if arg.kind == attrQuantity {
-					quantity = math.Float64frombits(uint64(arg.valI))
-				}
-			}
+	// not overridden, thus continue with the overlay base
+	if b.base != nil {
+		return b.base.resolveHnd(hnd, args...)
+	}
 
-			return data.quantityTemplates.execute(b.tag, quantity, args...), true
-		}
+	// handle is valid but this bundle does not contain it
+	// slow O(n) fallback propagation through the fallback chain and all prioritized bundles
+	if data, ok := b.parent.matchStrData(b.tag, hnd); ok {
+		return b.execute(data, args...)
 	}
 
 	return "", false
 }
 
+func (b *Bundle) fuzzyMessage(hnd int32, args ...Attr) (string, bool) {
+	if data, ok := b.at(hnd); ok {
+		return b.execute(data, args...)
+	}
+
+	return "", false
+}
+
+func (b *Bundle) execute(data strData, args ...Attr) (string, bool) {
+	switch data.kind {
+	case MessageString:
+		return data.constStr, true
+	case MessageVarString:
+		return data.template.Execute(args...), true
+	case MessageQuantities:
+		var quantity float64
+		for _, arg := range args {
+			if arg.kind == attrQuantity {
+				quantity = math.Float64frombits(uint64(arg.valI))
+			}
+		}
+
+		return data.quantityTemplates.execute(b.tag, quantity, args...), true
+	default:
+		return "", false
+	}
+}
+
 // String returns a static plain localized string or falls through sibling bundles.
 func (b *Bundle) String(id StrHnd) (string, bool) {
-	// fast path
-	if data, ok := b.strings.At(int(id)); ok && data.kind == MessageString {
+	// fast path including fallbacks
+	if data, ok := b.resolvedAt(int32(id)); ok {
+		return data.constStr, data.kind == MessageString
+	}
+
+	// slower path, if not flushed
+	if data, ok := b.at(int32(id)); ok && data.kind == MessageString {
 		return data.constStr, true
 	}
 
+	if b.base != nil {
+		return b.base.String(id)
+	}
+
 	// slow O(n) fallback propagation through all prioritized bundles
 	return b.parent.MatchString(b.tag, id)
 }
@@ -168,11 +226,24 @@ func (b *Bundle) VarString(id VarStrHnd, args ...Attr) (string, bool) {
 	// same effort as just comparing an integer variable. On average, most variable names are short and differ in the first
 	// few bytes, thus even when comparing with SIMD optimizations, it will not change the instruction count much.
 
-	// fast path
-	if data, ok := b.strings.At(int(id)); ok && data.kind == MessageVarString {
+	// fast path including fallbacks
+	if data, ok := b.resolvedAt(int32(id)); ok {
+		if data.kind != MessageVarString {
+			return "", false
+		}
+
 		return data.template.Execute(args...), true
 	}
 
+	// slower path, if not flushed
+	if data, ok := b.at(int32(id)); ok && data.kind == MessageVarString {
+		return data.template.Execute(args...), true
+	}
+
+	if b.base != nil {
+		return b.base.VarString(id, args...)
+	}
+
 	// slow O(n) fallback propagation through all prioritized bundles
 	return b.parent.MatchVarString(b.tag, id, args...)
 }
@@ -182,36 +253,50 @@ func (b *Bundle) VarString(id VarStrHnd, args ...Attr) (string, bool) {
 // know how the float is formatted (e.g. as 1.0 or just as 1). However, besides this special case, we are still
 // better than gettext or Android (e.g. as 1 Gopher vs 1.5 Gophers vs 1.00 Gophers (which we can't detect)).
 func (b *Bundle) QuantityString(id QStrHnd, quantity float64, args ...Attr) (string, bool) {
-	// fast path
-	if data, ok := b.strings.At(int(id)); ok && data.kind == MessageQuantities {
+	// fast path including fallbacks
+	if data, ok := b.resolvedAt(int32(id)); ok {
+		if data.kind != MessageQuantities {
+			return "", false
+		}
+
+		return data.quantityTemplates.execute(b.tag, quantity, args...), true
+	}
+
+	// slower path, if not flushed
+	if data, ok := b.at(int32(id)); ok && data.kind == MessageQuantities {
 		return data.quantityTemplates.execute(b.tag, quantity, args...), true
 	}
 
+	if b.base != nil {
+		return b.base.QuantityString(id, quantity, args...)
+	}
+
 	// slow O(n) fallback propagation through all prioritized bundles
 	return b.parent.MatchQuantityString(b.tag, id, quantity, args...)
 }
 
-// StringLiteral returns the raw literal, if available. There is no fallthrough.
+// StringLiteral returns the raw literal, if available. There is no fallthrough, thus a region only returns its
+// differing messages, see [Resources.AddRegion].
 func (b *Bundle) StringLiteral(id StrHnd) (string, bool) {
-	if str, ok := b.strings.At(int(id)); ok && str.kind == MessageString {
+	if str, ok := b.own(int32(id)); ok && str.kind == MessageString {
 		return str.constStr, true
 	}
 
 	return "", false
 }
 
-// VarStringLiteral returns the raw literal, if available. There is no fallthrough.
+// VarStringLiteral returns the raw literal, if available. There is no fallthrough, see [Bundle.StringLiteral].
 func (b *Bundle) VarStringLiteral(id StrHnd) (string, bool) {
-	if str, ok := b.strings.At(int(id)); ok && str.kind == MessageVarString {
+	if str, ok := b.own(int32(id)); ok && str.kind == MessageVarString {
 		return str.template.raw, true
 	}
 
 	return "", false
 }
 
-// QuantityStringLiterals returns the raw literal, if available. There is no fallthrough.
+// QuantityStringLiterals returns the raw literal, if available. There is no fallthrough, see [Bundle.StringLiteral].
 func (b *Bundle) QuantityStringLiterals(id QStrHnd) (Quantities, bool) {
-	if str, ok := b.strings.At(int(id)); ok && str.kind == MessageVarString {
+	if str, ok := b.own(int32(id)); ok && str.kind == MessageQuantities {
 		return str.quantityTemplates.raw, true
 	}
 
@@ -220,72 +305,154 @@ func (b *Bundle) QuantityStringLiterals(id QStrHnd) (Quantities, bool) {
 
 // Update validates and updates the related message data values for the according localization. Note, that this
 // will switch the bundle implementation into mutation mode, so after your mutation you may want to [Bundle.Flush]
-// to optimize performance.
-func (b *Bundle) Update(msg Message) error {
-	if msg.Key == "" {
-		return fmt.Errorf("cannot update message without key")
+// to optimize performance. If a [Store] is attached to the parent, the message is persisted first and the bundle
+// is only updated if that succeeds. Each update is recorded as a [Revision], see [Resources.History].
+// Updates of overlays are neither persisted in the parent's store nor recorded.
+func (b *Bundle) Update(msg Message, opts ...UpdateOption) error {
+	hnd, msg, data, err := b.prepare(msg)
+	if err != nil {
+		return err
 	}
 
-	hnd, ok := b.parent.reverseHandles.Get(msg.Key)
-	if !ok {
-		return fmt.Errorf("key has no associated string handle: %v", msg.Key)
+	var options updateOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	expectedType := b.parent.MessageType(msg.Key)
+	b.updates.Lock()
+	old := b.MessageByKey(msg.Key)
+
+	if b.base == nil {
+		err := b.persist(Revision{
+			Tag:      b.tag,
+			Key:      msg.Key,
+			Old:      old,
+			New:      msg,
+			Author:   options.author,
+			Metadata: options.metadata,
+			Time:     time.Now(),
+		}, func(store Store) error {
+			return store.Save(b.tag, msg)
+		})
 
-	requiresInsert := false
-	if msg.Kind == MessageUndefined {
-		msg.Kind = expectedType
-		requiresInsert = true
+		if err != nil {
+			b.updates.Unlock()
+			return err
+		}
 	}
 
-	if !requiresInsert {
-		if _, ok := b.strings.At(int(hnd)); !ok {
-			requiresInsert = true
-		}
+	b.strings.Set(slot(hnd), data)
+	b.updates.Unlock()
+	b.notify(old, msg, options)
+
+	return nil
+}
+
+// notify publishes the event of a changed message. Changes of overlays are not emitted.
+func (b *Bundle) notify(old, msg Message, options updateOptions) {
+	if b.base != nil {
+		return
 	}
 
-	if b.parent.MessageType(msg.Key) != msg.Kind {
-		return fmt.Errorf("given message type does not match registered message type for key: %v", msg.Key)
+	b.parent.publish(Event{
+		Kind:     EventMessageUpdated,
+		Key:      msg.Key,
+		Tag:      b.tag,
+		Old:      old,
+		New:      msg,
+		Author:   options.author,
+		Metadata: options.metadata,
+	})
+}
+
+// Delete removes the message of the given key from this bundle. The handle stays valid, so that lookups just
+// fall through to the other languages as if the message has never been declared for this language. Like
+// [Bundle.Update], the removal is persisted as a tombstone in an attached [Store], so that a declared message does
+// not come back after a restart, and recorded as a [Revision] with an undefined new message. Deleting from an
+// overlay removes the override.
+func (b *Bundle) Delete(key Key, opts ...UpdateOption) error {
+	hnd, ok := b.parent.reverseHandles.Get(key)
+	if !ok {
+		return fmt.Errorf("key has no associated string handle: %v", key)
 	}
 
-	var data strData
-	if !requiresInsert {
-		d, ok := b.strings.At(int(hnd))
-		if !ok {
-			return fmt.Errorf("strings table is missing index %v", hnd)
-		}
+	key = b.parent.canonicalKey(key)
 
-		data = d
+	var options updateOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	data.kind = expectedType
+	b.updates.Lock()
+	old := b.MessageByKey(key)
+
+	if b.base == nil {
+		err := b.persist(Revision{
+			Tag:      b.tag,
+			Key:      key,
+			Old:      old,
+			New:      Message{Key: key},
+			Author:   options.author,
+			Metadata: options.metadata,
+			Time:     time.Now(),
+		}, func(store Store) error {
+			return store.Save(b.tag, Message{Key: key})
+		})
 
-	switch msg.Kind {
-	case MessageString:
-		data.constStr = msg.Value
-	case MessageVarString:
-		tpl, err := ParseTemplate(msg.Value)
 		if err != nil {
-			return fmt.Errorf("failed to parse template for %v: %w", msg.Key, err)
+			b.updates.Unlock()
+			return err
 		}
+	}
 
-		data.template = tpl
-	case MessageQuantities:
-		qtpls, err := parseQuantityTemplates(msg.Quantities)
-		if err != nil {
-			return fmt.Errorf("failed to parse quantities for %v: %w", msg.Key, err)
-		}
-		data.quantityTemplates = qtpls
-	default:
-		return fmt.Errorf("unsupported message type %v", msg.Kind)
+	if _, ok := b.strings.At(slot(hnd)); ok {
+		b.strings.Set(slot(hnd), strData{})
 	}
+	b.updates.Unlock()
 
-	b.strings.Set(int(hnd), data)
+	b.notify(old, Message{Key: key}, options)
 
 	return nil
 }
 
+// prepare validates the given message against the registered key and returns the normalized message and
+// its pre-compiled data.
+func (b *Bundle) prepare(msg Message) (int32, Message, strData, error) {
+	return b.parent.prepare(msg)
+}
+
+// prepare is like [Bundle.prepare] but does not require a bundle, e.g. to validate a message before its
+// language is added.
+func (r *Resources) prepare(msg Message) (int32, Message, strData, error) {
+	if msg.Key == "" {
+		return 0, msg, strData{}, fmt.Errorf("cannot update message without key")
+	}
+
+	hnd, ok := r.reverseHandles.Get(msg.Key)
+	if !ok {
+		return 0, msg, strData{}, fmt.Errorf("key has no associated string handle: %v", msg.Key)
+	}
+
+	msg.Key = r.canonicalKey(msg.Key)
+
+	expectedType := r.MessageType(msg.Key)
+
+	if msg.Kind == MessageUndefined {
+		msg.Kind = expectedType
+	}
+
+	if expectedType != msg.Kind {
+		return 0, msg, strData{}, fmt.Errorf("given message type does not match registered message type for key: %v", msg.Key)
+	}
+
+	data, err := newStrData(msg)
+	if err != nil {
+		return 0, msg, strData{}, err
+	}
+
+	return hnd, msg, data, nil
+}
+
 // MessageTypeByKey lookup if the kind within this bundle. It does not fallthrough or checks otherwise for consistency.
 // If the key is not contained in this Bundle the [MessageUndefined] value is returned.
 func (b *Bundle) MessageTypeByKey(key Key) MessageType {
@@ -294,7 +461,7 @@ func (b *Bundle) MessageTypeByKey(key Key) MessageType {
 		return MessageUndefined
 	}
 
-	dat, ok := b.strings.At(int(v))
+	dat, ok := b.strings.At(slot(v))
 	if !ok {
 		return MessageUndefined
 	}
@@ -311,12 +478,20 @@ func (b *Bundle) message(hnd int) Message {
 	return b.MessageByKey(key)
 }
 
+// Version returns a counter which increases with every change of the own messages of this bundle, e.g. by
+// [Resources.AddString], [Bundle.Update] or [Bundle.Delete]. Changes of other bundles or of the priorities
+// are only reflected by [Resources.Version].
+func (b *Bundle) Version() uint64 {
+	return b.strings.version.Load()
+}
+
 func (b *Bundle) Tag() language.Tag {
 	return b.tag
 }
 
 // MessageByKey returns the raw and unparsed Message. There is no fallthrough logic applied. Thus, if
-// not defined in this bundle, a Message with [i18n.MessageUndefined] is returned.
+// not defined in this bundle, a Message with [i18n.MessageUndefined] is returned. If the key is an alias, the
+// returned Message contains the declared key, see [Resources.Alias].
 func (b *Bundle) MessageByKey(key Key) Message {
 	v, ok := b.parent.reverseHandles.Get(key)
 	if !ok {
@@ -325,30 +500,16 @@ func (b *Bundle) MessageByKey(key Key) Message {
 		}
 	}
 
-	dat, ok := b.strings.At(int(v))
+	key = b.parent.canonicalKey(key)
+
+	dat, ok := b.strings.At(slot(v))
 	if !ok {
 		return Message{
 			Key: key,
 		}
 	}
 
-	var val string
-	switch dat.kind {
-	case MessageString:
-		val = dat.constStr
-	case MessageVarString:
-		val = dat.template.raw
-	default:
-		// plurals
-	}
-	msg := Message{
-		Key:        key,
-		Kind:       dat.kind,
-		Value:      val,
-		Quantities: dat.quantityTemplates.raw,
-	}
-
-	return msg
+	return dat.message(key)
 }
 
 // Parent returns the enclosing [Resources].
@@ -0,0 +1,358 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/worldiety/i18n/parser"
+	"golang.org/x/text/language"
+)
+
+// catalogValue is a single translation as read from a catalog file, before it is checked against the registered keys.
+type catalogValue struct {
+	key        Key
+	value      string
+	quantities Quantities
+	plural     bool
+}
+
+type catalog struct {
+	name   string
+	tag    language.Tag
+	values []catalogValue
+}
+
+// LoadFS discovers all per-language catalog files which match the given pattern (see [fs.Glob]) and merges
+// their translations into this instance. This also works with an embed.FS to ship translation overrides inside
+// the binary. The language is inferred from the file name, e.g. locales/de.json, locales/en-US.po
+// or locales/messages.en_GB.json. Supported formats are
+//   - .json: a flat object of keys to either a string or an object with the plural categories zero, one,
+//     two, few, many and other.
+//   - .po: gettext catalogs, see the notes below.
+//
+// Translations for already declared keys must match the declared message type, thus the value of a [MessageString]
+// must not contain variables. Unknown keys are added, where a string containing variables becomes a
+// [MessageVarString]. If a gettext entry has a msgctxt, it is used as the key, otherwise the msgid. The msgstr[n]
+// plural forms are mapped onto the CLDR categories used by the language. All files are parsed and validated
+// before anything is applied, thus either all or no translations are merged. The messages of all affected
+// languages are published in a single step, so that concurrent readers never observe a partially applied merge.
+// Afterward, the instance is flushed.
+func (r *Resources) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+
+	slices.Sort(names)
+
+	var catalogs []catalog
+	for _, name := range names {
+		cat, err := readCatalog(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		catalogs = append(catalogs, cat)
+	}
+
+	return r.mergeCatalogs(catalogs, nil)
+}
+
+func readCatalog(fsys fs.FS, name string) (catalog, error) {
+	tag, err := tagFromFileName(name)
+	if err != nil {
+		return catalog{}, err
+	}
+
+	buf, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return catalog{}, err
+	}
+
+	var values []catalogValue
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		values, err = parseJSONCatalog(buf)
+	case ".po":
+		values, err = parsePO(tag, buf)
+	default:
+		return catalog{}, fmt.Errorf("unsupported catalog format: %s", name)
+	}
+
+	if err != nil {
+		return catalog{}, fmt.Errorf("cannot parse catalog %s: %w", name, err)
+	}
+
+	return catalog{name: name, tag: tag, values: values}, nil
+}
+
+// tagFromFileName infers the language from the last dot separated segment of the file name without extension.
+func tagFromFileName(name string) (language.Tag, error) {
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	if idx := strings.LastIndex(base, "."); idx >= 0 {
+		base = base[idx+1:]
+	}
+
+	tag, err := language.Parse(strings.ReplaceAll(base, "_", "-"))
+	if err != nil {
+		return language.Und, fmt.Errorf("cannot infer language from file name %s: %w", name, err)
+	}
+
+	return tag, nil
+}
+
+func parseJSONCatalog(buf []byte) ([]catalogValue, error) {
+	var tmp map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &tmp); err != nil {
+		return nil, err
+	}
+
+	var res []catalogValue
+	for key, raw := range tmp {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) > 0 && raw[0] == '{' {
+			var quantities Quantities
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&quantities); err != nil {
+				return nil, fmt.Errorf("invalid quantities for %s: %w", key, err)
+			}
+
+			res = append(res, catalogValue{key: Key(key), quantities: quantities, plural: true})
+			continue
+		}
+
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+
+		res = append(res, catalogValue{key: Key(key), value: str})
+	}
+
+	slices.SortFunc(res, func(a, b catalogValue) int {
+		return strings.Compare(string(a.key), string(b.key))
+	})
+
+	return res, nil
+}
+
+// mergeCatalogs validates all catalogs against the registered keys and applies them only if everything is valid.
+// Newly declared keys and languages become visible first, their messages are swapped in atomically afterward.
+// The messages of the removed keys are deleted from the given languages within the same step.
+func (r *Resources) mergeCatalogs(catalogs []catalog, removed map[language.Tag][]Key) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	// infer message types of unknown keys across all catalogs
+	inferred := map[Key]MessageType{}
+	for _, cat := range catalogs {
+		for _, v := range cat.values {
+			if r.MessageType(v.key) != MessageUndefined {
+				continue
+			}
+
+			kind := MessageString
+			switch {
+			case v.plural:
+				kind = MessageQuantities
+			case containsVars(v.value):
+				kind = MessageVarString
+			}
+
+			if prev, ok := inferred[v.key]; ok && prev != kind {
+				if prev == MessageQuantities || kind == MessageQuantities {
+					return fmt.Errorf("%s: %v is declared as quantity string and as plain string", cat.name, v.key)
+				}
+
+				kind = MessageVarString
+			}
+
+			inferred[v.key] = kind
+		}
+	}
+
+	type pending struct {
+		tag  language.Tag
+		key  Key
+		data strData
+	}
+
+	var todo []pending
+	var errs []error
+	for _, cat := range catalogs {
+		for _, v := range cat.values {
+			kind, ok := inferred[v.key]
+			if !ok {
+				kind = r.MessageType(v.key)
+			}
+
+			if (kind == MessageQuantities) != v.plural {
+				errs = append(errs, fmt.Errorf("%s: %v does not match the declared message type", cat.name, v.key))
+				continue
+			}
+
+			// a plain string would silently show the variables as literal text
+			if kind == MessageString {
+				if vars, err := parseVars(v.value); err == nil && vars {
+					errs = append(errs, fmt.Errorf("%s: %v contains variables but is declared as plain string", cat.name, v.key))
+					continue
+				}
+			}
+
+			data, err := newStrData(Message{Key: v.key, Kind: kind, Value: v.value, Quantities: v.quantities})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", cat.name, err))
+				continue
+			}
+
+			todo = append(todo, pending{tag: cat.tag, key: v.key, data: data})
+		}
+	}
+
+	// check the handle capacity first, so that a failing merge does not leave keys without messages behind
+	added := map[Key]struct{}{}
+	for _, p := range todo {
+		if _, ok := r.reverseHandles.Get(p.key); !ok {
+			added[p.key] = struct{}{}
+		}
+	}
+
+	if int(r.lastHandle.Load())+len(added) > hndSlotMask {
+		errs = append(errs, fmt.Errorf("cannot add %d keys: %w", len(added), ErrTooManyKeys))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// stage the changes per bundle, so that they can be published in a single step
+	type change struct {
+		slot int
+		data strData
+	}
+
+	var bundles []*Bundle
+	changes := map[*Bundle][]change{}
+	var events []Event
+	for _, p := range todo {
+		hnd, ok := r.reverseHandles.Get(p.key)
+		if !ok {
+			var err error
+			if hnd, err = r.register(p.key, p.data.kind); err != nil {
+				return err
+			}
+		}
+
+		bnd, ok := r.children.Get(p.tag)
+		if !ok {
+			bnd = r.addBundle(p.tag)
+		}
+
+		if _, ok := changes[bnd]; !ok {
+			bundles = append(bundles, bnd)
+		}
+
+		old := bnd.MessageByKey(p.key)
+		changes[bnd] = append(changes[bnd], change{slot: slot(hnd), data: p.data})
+		events = append(events, Event{Kind: EventMessageUpdated, Key: old.Key, Tag: p.tag, Old: old, New: p.data.message(old.Key)})
+	}
+
+	tags := slices.SortedFunc(maps.Keys(removed), func(a, b language.Tag) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	for _, tag := range tags {
+		bnd, ok := r.children.Get(tag)
+		if !ok {
+			continue
+		}
+
+		for _, key := range removed[tag] {
+			hnd, ok := r.reverseHandles.Get(key)
+			if !ok {
+				continue
+			}
+
+			old := bnd.MessageByKey(key)
+			if !old.Valid() {
+				continue
+			}
+
+			if _, ok := changes[bnd]; !ok {
+				bundles = append(bundles, bnd)
+			}
+
+			changes[bnd] = append(changes[bnd], change{slot: slot(hnd), data: strData{}})
+			events = append(events, Event{Kind: EventMessageUpdated, Key: old.Key, Tag: tag, Old: old, New: Message{Key: old.Key}})
+		}
+	}
+
+	targets := make([]*bufferedSlice[strData], len(bundles))
+	for i, bnd := range bundles {
+		targets[i] = &bnd.strings
+	}
+
+	swapSlices(targets, func(i int, data []strData) []strData {
+		for _, c := range changes[bundles[i]] {
+			if c.slot >= len(data) {
+				data = append(data, make([]strData, c.slot-len(data)+1)...)
+			}
+
+			data[c.slot] = c.data
+		}
+
+		return data
+	}, func() {
+		// invalidate the resolved tables together with the swap
+		r.generation.Add(1)
+	})
+
+	for _, evt := range events {
+		r.emit(evt)
+	}
+
+	r.flush()
+
+	return nil
+}
+
+func containsVars(text string) bool {
+	vars, err := parseVars(text)
+	if err != nil {
+		// let the actual validation report the error
+		return strings.Contains(text, "{")
+	}
+
+	return vars
+}
+
+// parseVars reports whether the text is a valid template which contains variables.
+func parseVars(text string) (bool, error) {
+	tokens, err := parser.Parse(text)
+	if err != nil {
+		return false, err
+	}
+
+	for _, token := range tokens {
+		if token.Type == parser.VarToken {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
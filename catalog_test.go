@@ -0,0 +1,183 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_LoadFS(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{language.English: {One: "one apple", Other: "{n} apples"}}))
+
+	fsys := fstest.MapFS{
+		"locales/de.json": {Data: []byte(`{
+			"app.hello": "hallo",
+			"app.apples": {"one": "ein Apfel", "other": "{n} Äpfel"},
+			"app.greet": "Hallo {name}"
+		}`)},
+		"locales/pl.po": {Data: []byte(`
+msgid ""
+msgstr ""
+"Language: pl\n"
+
+msgctxt "app.hello"
+msgid "hello"
+msgstr "cześć"
+
+msgid "app.apples"
+msgid_plural "apples"
+msgstr[0] "jabłko"
+msgstr[1] "{n} jabłka"
+msgstr[2] "{n} jabłek"
+`)},
+		"locales/README.md": {Data: []byte("ignored")},
+	}
+
+	if err := res.LoadFS(fsys, "locales/*.[jp][so]*"); err != nil {
+		t.Fatal(err)
+	}
+
+	de := res.MustMatchBundle(language.German)
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(de, 3, i18n.Int("n", 3)); v != "3 Äpfel" {
+		t.Fatal(v)
+	}
+
+	if v := de.Resolve("app.greet", i18n.String("name", "Torben")); v != "Hallo Torben" {
+		t.Fatal(v)
+	}
+
+	pl := res.MustMatchBundle(language.Polish)
+	if v := hello.Get(pl); v != "cześć" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(pl, 3, i18n.Int("n", 3)); v != "3 jabłka" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(pl, 5, i18n.Int("n", 5)); v != "5 jabłek" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_LoadFSPluralForms(t *testing.T) {
+	var res i18n.Resources
+	apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{language.English: {One: "one apple", Other: "{n} apples"}}))
+
+	// the gettext order of the latvian forms is one, other, zero but CLDR orders zero, one, other
+	fsys := fstest.MapFS{
+		"lv.po": {Data: []byte(`
+msgid ""
+msgstr ""
+"Language: lv\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n != 0 ? 1 : 2);\n"
+
+msgid "app.apples"
+msgid_plural "apples"
+msgstr[0] "{n} ābols"
+msgstr[1] "{n} āboli"
+msgstr[2] "{n} ābolu"
+`)},
+	}
+
+	if err := res.LoadFS(fsys, "*.po"); err != nil {
+		t.Fatal(err)
+	}
+
+	lv := res.MustMatchBundle(language.Latvian)
+	for n, want := range map[int]string{1: "1 ābols", 21: "21 ābols", 5: "5 āboli", 11: "11 āboli"} {
+		if v := apples.Get(lv, float64(n), i18n.Int("n", n)); v != want {
+			t.Fatal(n, v)
+		}
+	}
+
+	fsys["lv.po"].Data = []byte(`
+msgid ""
+msgstr "Plural-Forms: nplurals=2; plural=(n != 1 ? 2 : 0);\n"
+`)
+	if err := res.LoadFS(fsys, "*.po"); err == nil {
+		t.Fatal("expected plural index out of range")
+	}
+}
+
+func TestResources_LoadFSInvalid(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+
+	fsys := fstest.MapFS{
+		"de.json": {Data: []byte(`{"app.hello": "hallo"}`)},
+		"fr.json": {Data: []byte(`{"app.hello": {"one": "un"}}`)},
+	}
+
+	if err := res.LoadFS(fsys, "*.json"); err == nil {
+		t.Fatal("expected type mismatch")
+	}
+
+	if _, ok := res.Bundle(language.German); ok {
+		t.Fatal("nothing must be applied")
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.English)); v != "hello" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_LoadFSAtomic(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.German: "0", language.French: "0"}))
+	res.Flush()
+
+	de := res.MustMatchBundle(language.German)
+	fr := res.MustMatchBundle(language.French)
+
+	done := make(chan struct{})
+	failed := make(chan string, 1)
+	go func() {
+		defer close(failed)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			// the french translation is never older than the german one, which has been read before
+			a, _ := strconv.Atoi(hello.Get(de))
+			b, _ := strconv.Atoi(hello.Get(fr))
+			if b < a {
+				failed <- fmt.Sprintf("de=%d fr=%d", a, b)
+				return
+			}
+		}
+	}()
+
+	for i := 1; i <= 500; i++ {
+		v := []byte(`{"app.hello": "` + strconv.Itoa(i) + `"}`)
+		if err := res.LoadFS(fstest.MapFS{"de.json": {Data: v}, "fr.json": {Data: v}}, "*.json"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(done)
+	if msg, ok := <-failed; ok {
+		t.Fatal("observed a partially applied reload:", msg)
+	}
+}
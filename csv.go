@@ -0,0 +1,383 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	csvColKey         = "key"
+	csvColCategory    = "category"
+	csvColHint        = "hint"
+	csvColVarHints    = "var_hints"
+	csvColDeprecation = "deprecation"
+	csvColAliases     = "aliases"
+)
+
+// CSVOptions configures the spreadsheet exchange format used by [Resources.ExportCSV] and [Resources.ImportCSV].
+type CSVOptions struct {
+	// Comma is the field delimiter. If zero, a comma is used. Use '\t' to read and write TSV.
+	Comma rune
+
+	// Tags restricts and orders the exported language columns. If empty, all available languages are exported
+	// in stable order. The importer ignores this field and uses the header instead.
+	Tags []language.Tag
+
+	// DryRun only validates the imported rows and calculates the report without applying any change.
+	DryRun bool
+
+	// Namespace restricts the export to the keys of the given namespace prefix. See [Resources.Namespace].
+	Namespace string
+
+	// OmitDeprecated excludes deprecated keys from the export. Otherwise, they are marked in the
+	// deprecation column. See [Resources.Deprecate].
+	OmitDeprecated bool
+}
+
+func (o CSVOptions) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+
+	return o.Comma
+}
+
+// ExportCSV writes one row per key with a column per language, so that non-technical reviewers can work
+// with the texts in a spreadsheet. The first columns contain the key, the plural category, the localization hint,
+// the variable hints, the deprecation notice and the space separated aliases. Quantity strings are expanded into
+// one sub-row per used plural category.
+func (r *Resources) ExportCSV(dst io.Writer, opts CSVOptions) error {
+	tags := opts.Tags
+	if len(tags) == 0 {
+		tags = r.Tags()
+	}
+
+	var bundles []*Bundle
+	for _, tag := range tags {
+		bnd, _ := r.Bundle(tag) // a nil bundle just exports empty cells
+		bundles = append(bundles, bnd)
+	}
+
+	w := csv.NewWriter(dst)
+	w.Comma = opts.comma()
+
+	header := []string{csvColKey, csvColCategory, csvColHint, csvColVarHints, csvColDeprecation, csvColAliases}
+	for _, tag := range tags {
+		header = append(header, tag.String())
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, key := range r.SortedKeys() {
+		if opts.Namespace != "" && !strings.HasPrefix(string(key), opts.Namespace+".") {
+			continue
+		}
+
+		var deprecation string
+		if replacement, ok := r.Deprecation(key); ok {
+			if opts.OmitDeprecated {
+				continue
+			}
+
+			deprecation = "deprecated"
+			if replacement != "" {
+				deprecation = "replaced by " + string(replacement)
+			}
+		}
+
+		hint := r.Hint(key)
+		varHints := formatVarHints(r.VarHints(key))
+		aliases := formatAliases(r.AliasesOf(key))
+
+		msgs := make([]Message, len(bundles))
+		for i, bnd := range bundles {
+			if bnd != nil {
+				msgs[i] = bnd.MessageByKey(key)
+			}
+		}
+
+		if r.MessageType(key) != MessageQuantities {
+			row := []string{string(key), "", hint, varHints, deprecation, aliases}
+			for _, msg := range msgs {
+				row = append(row, msg.Value)
+			}
+
+			if err := w.Write(row); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, category := range quantityCategories {
+			used := category == "other"
+			for _, msg := range msgs {
+				if v, _ := msg.Quantities.category(category); v != "" {
+					used = true
+				}
+			}
+
+			if !used {
+				continue
+			}
+
+			row := []string{string(key), category, hint, varHints, deprecation, aliases}
+			for _, msg := range msgs {
+				v, _ := msg.Quantities.category(category)
+				row = append(row, v)
+			}
+
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func formatVarHints(hints iter.Seq[VarHint]) string {
+	var tmp []string
+	for hint := range hints {
+		tmp = append(tmp, hint.Name+": "+hint.Description)
+	}
+
+	return strings.Join(tmp, "; ")
+}
+
+// CSVChange describes a single message which differs between the imported rows and the current bundle.
+type CSVChange struct {
+	Tag     language.Tag
+	Old     Message
+	New     Message
+	Applied bool // true, if the change has been applied and not reverted
+}
+
+// CSVReport contains the calculated difference of an import.
+type CSVReport struct {
+	Changes []CSVChange
+	Applied bool
+}
+
+// String renders the report as a human-readable diff.
+func (r CSVReport) String() string {
+	var tmp strings.Builder
+	for _, change := range r.Changes {
+		tmp.WriteString(fmt.Sprintf("%s [%s]\n", change.New.Key, change.Tag))
+		for _, line := range strings.Split(strings.TrimSuffix(change.Old.String(), "\n"), "\n") {
+			if change.Old.Valid() {
+				tmp.WriteString("- " + line + "\n")
+			}
+		}
+
+		for _, line := range strings.Split(strings.TrimSuffix(change.New.String(), "\n"), "\n") {
+			tmp.WriteString("+ " + line + "\n")
+		}
+	}
+
+	return tmp.String()
+}
+
+// ImportCSV reads rows in the format written by [Resources.ExportCSV], validates all of them and applies
+// every changed message using [Bundle.Update]. Empty cells are interpreted as unchanged. The hint columns
+// are informational only and are ignored. Rows using an alias are applied to the declared key. If any row is
+// invalid, nothing is applied at all. Missing languages are added using [Resources.AddLanguage]. Use
+// [CSVOptions.DryRun] to just calculate the report. If a change cannot be applied, e.g. because an attached
+// [Store] fails, the already applied changes are reverted and [CSVChange.Applied] tells which changes could not
+// be reverted either.
+func (r *Resources) ImportCSV(src io.Reader, opts CSVOptions) (CSVReport, error) {
+	cr := csv.NewReader(src)
+	cr.Comma = opts.comma()
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return CSVReport{}, fmt.Errorf("cannot read csv header: %w", err)
+	}
+
+	colKey := slices.Index(header, csvColKey)
+	colCategory := slices.Index(header, csvColCategory)
+	if colKey < 0 {
+		return CSVReport{}, fmt.Errorf("csv header has no %q column", csvColKey)
+	}
+
+	type langCol struct {
+		idx int
+		tag language.Tag
+	}
+
+	var langCols []langCol
+	for i, name := range header {
+		switch name {
+		case csvColKey, csvColCategory, csvColHint, csvColVarHints, csvColDeprecation, csvColAliases:
+			continue
+		}
+
+		tag, err := language.Parse(name)
+		if err != nil {
+			return CSVReport{}, fmt.Errorf("invalid language column %q: %w", name, err)
+		}
+
+		langCols = append(langCols, langCol{idx: i, tag: tag})
+	}
+
+	type tagKey struct {
+		tag language.Tag
+		key Key
+	}
+
+	pending := map[tagKey]Message{}
+	var order []tagKey
+	var errs []error
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return CSVReport{}, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		if colKey >= len(row) || row[colKey] == "" {
+			errs = append(errs, fmt.Errorf("line %d: missing key", line))
+			continue
+		}
+
+		key := r.canonicalKey(Key(row[colKey]))
+		var category string
+		if colCategory >= 0 && colCategory < len(row) {
+			category = row[colCategory]
+		}
+
+		kind := r.MessageType(key)
+		switch {
+		case kind == MessageUndefined:
+			errs = append(errs, fmt.Errorf("line %d: unknown key %v", line, key))
+			continue
+		case kind == MessageQuantities && category == "":
+			errs = append(errs, fmt.Errorf("line %d: quantity string %v requires a category", line, key))
+			continue
+		case kind != MessageQuantities && category != "":
+			errs = append(errs, fmt.Errorf("line %d: string %v must not have a category", line, key))
+			continue
+		}
+
+		for _, lc := range langCols {
+			if lc.idx >= len(row) || row[lc.idx] == "" {
+				continue
+			}
+
+			tk := tagKey{tag: lc.tag, key: key}
+			msg, ok := pending[tk]
+			if !ok {
+				msg = Message{Key: key, Kind: kind}
+				if bnd, ok := r.Bundle(lc.tag); ok {
+					if cur := bnd.MessageByKey(key); cur.Valid() {
+						msg = cur
+					}
+				}
+
+				order = append(order, tk)
+			}
+
+			if kind == MessageQuantities {
+				if !msg.Quantities.setCategory(category, row[lc.idx]) {
+					errs = append(errs, fmt.Errorf("line %d: unknown plural category %q", line, category))
+				}
+			} else {
+				msg.Value = row[lc.idx]
+			}
+
+			pending[tk] = msg
+		}
+	}
+
+	var report CSVReport
+	for _, tk := range order {
+		msg := pending[tk]
+		if _, err := newStrData(msg); err != nil {
+			errs = append(errs, fmt.Errorf("%v [%v]: %w", tk.key, tk.tag, err))
+			continue
+		}
+
+		var old Message
+		if bnd, ok := r.Bundle(tk.tag); ok {
+			old = bnd.MessageByKey(tk.key)
+		}
+
+		if old.Valid() && old.Value == msg.Value && old.Quantities == msg.Quantities {
+			continue
+		}
+
+		report.Changes = append(report.Changes, CSVChange{Tag: tk.tag, Old: old, New: msg})
+	}
+
+	if len(errs) > 0 {
+		return report, errors.Join(errs...)
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for i, change := range report.Changes {
+		bnd, _ := r.AddLanguage(change.Tag)
+		if err := bnd.Update(change.New); err != nil {
+			return report, errors.Join(err, r.revertCSV(report.Changes[:i]))
+		}
+
+		report.Changes[i].Applied = true
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// revertCSV restores the old messages of the given applied changes in reverse order. Changes which cannot be
+// reverted stay marked as applied.
+func (r *Resources) revertCSV(changes []CSVChange) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		bnd, ok := r.Bundle(change.Tag)
+		if !ok {
+			continue
+		}
+
+		var err error
+		if change.Old.Valid() {
+			err = bnd.Update(change.Old)
+		} else {
+			err = bnd.Delete(change.New.Key)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot revert %v [%v]: %w", change.New.Key, change.Tag, err))
+			continue
+		}
+
+		changes[i].Applied = false
+	}
+
+	return errors.Join(errs...)
+}
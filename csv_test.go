@@ -0,0 +1,135 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_CSVRoundTrip(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}, i18n.LocalizationHint("greeting")))
+	apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{
+		language.English: {One: "one apple", Other: "{n} apples"},
+	}, i18n.LocalizationVarHint("n", "amount of apples")))
+	res.Flush()
+
+	var buf bytes.Buffer
+	if err := res.ExportCSV(&buf, i18n.CSVOptions{Comma: '\t'}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "key\tcategory\thint\tvar_hints\tdeprecation\taliases\tde\ten\n" +
+		"app.apples\tone\t\tn: amount of apples\t\t\t\tone apple\n" +
+		"app.apples\tother\t\tn: amount of apples\t\t\t\t{n} apples\n" +
+		"app.hello\t\tgreeting\t\t\t\thallo\thello\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+
+	edited := strings.Replace(buf.String(), "\t\tone apple", "\tein Apfel\tone apple", 1)
+	edited = strings.Replace(edited, "\thallo\t", "\tHallo!\t", 1)
+
+	report, err := res.ImportCSV(strings.NewReader(edited), i18n.CSVOptions{Comma: '\t', DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Changes) != 2 || report.Applied {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	de := res.MustMatchBundle(language.German)
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatalf("dry run must not apply changes: %s", v)
+	}
+
+	report, err = res.ImportCSV(strings.NewReader(edited), i18n.CSVOptions{Comma: '\t'})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Applied {
+		t.Fatal("expected applied report")
+	}
+
+	if v := hello.Get(de); v != "Hallo!" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(de, 1); v != "ein Apfel" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_ImportCSVInvalid(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddVarString("app.hello", i18n.Values{language.English: "hello {name}"}))
+
+	src := "key,category,en\napp.hello,,hello {name\napp.unknown,,foo\n"
+	if _, err := res.ImportCSV(strings.NewReader(src), i18n.CSVOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.English), i18n.String("name", "x")); v != "hello x" {
+		t.Fatal(v)
+	}
+}
+
+// failingStore rejects to save the messages of a single key.
+type failingStore struct {
+	*i18n.MemoryStore
+	key i18n.Key
+}
+
+func (s failingStore) Save(tag language.Tag, msg i18n.Message) error {
+	if msg.Key == s.key {
+		return errors.New("disk full")
+	}
+
+	return s.MemoryStore.Save(tag, msg)
+}
+
+func TestResources_ImportCSVRollback(t *testing.T) {
+	var res i18n.Resources
+	a := option.Must(res.AddString("app.a", i18n.Values{language.English: "a"}))
+	option.Must(res.AddString("app.b", i18n.Values{language.English: "b"}))
+	if err := res.Attach(failingStore{MemoryStore: i18n.NewMemoryStore(), key: "app.b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	src := "key,category,hint,var_hints,deprecation,aliases,en\n" +
+		"app.a,,,,,,A\n" +
+		"app.b,,,,,,B\n"
+
+	report, err := res.ImportCSV(strings.NewReader(src), i18n.CSVOptions{})
+	if err == nil {
+		t.Fatal("expected store error")
+	}
+
+	if report.Applied || len(report.Changes) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	for _, change := range report.Changes {
+		if change.Applied {
+			t.Fatalf("expected reverted change: %+v", change)
+		}
+	}
+
+	if v := a.Get(res.MustMatchBundle(language.English)); v != "a" {
+		t.Fatal(v)
+	}
+}
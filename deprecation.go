@@ -0,0 +1,43 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import "fmt"
+
+// Deprecate marks the given key as deprecated. Deprecated keys stay fully functional, because their handles
+// may still be in use, however exporters omit or mark them, so that translators do not waste time on obsolete
+// entries. The optional replacement tells which key should be used instead. Use [Bundle.Delete] to clear
+// the values.
+func (r *Resources) Deprecate(key Key, replacement Key) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.reverseHandles.Get(key); !ok {
+		return fmt.Errorf("key has no associated string handle: %v", key)
+	}
+
+	if replacement != "" {
+		if _, ok := r.reverseHandles.Get(replacement); !ok {
+			return fmt.Errorf("replacement key has no associated string handle: %v", replacement)
+		}
+	}
+
+	if replacement != "" {
+		replacement = r.canonicalKey(replacement)
+	}
+
+	r.deprecations.Put(r.canonicalKey(key), replacement)
+	r.mutated()
+	return nil
+}
+
+// Deprecation returns true if the key or the key of the given alias has been deprecated and its optional
+// replacement. See [Resources.Deprecate].
+func (r *Resources) Deprecation(key Key) (replacement Key, deprecated bool) {
+	return r.deprecations.Get(r.canonicalKey(key))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestBundle_Delete(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	res.SetPriorities(language.English, language.German)
+	res.Flush()
+
+	de := res.MustMatchBundle(language.German)
+	if err := de.Delete("app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(de); v != "hello" {
+		t.Fatal("expected fall through", v)
+	}
+
+	if msg := de.MessageByKey("app.hello"); msg.Valid() {
+		t.Fatal(msg)
+	}
+
+	en := res.MustMatchBundle(language.English)
+	if err := en.Delete("app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the type is still known, even if no bundle declares the key anymore
+	if err := de.Update(i18n.Message{Key: "app.hello", Value: "moin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(en); v != "moin" {
+		t.Fatal(v)
+	}
+
+	revs := option.Must(res.History(language.English, "app.hello"))
+	if err := res.Revert(language.English, "app.hello", revs[0].ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResources_Deprecate(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.old", i18n.Values{language.English: "old"}))
+	option.Must(res.AddString("app.new", i18n.Values{language.English: "new"}))
+
+	if err := res.Deprecate("app.old", "app.new"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.Deprecate("app.unknown", ""); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if replacement, ok := res.Deprecation("app.old"); !ok || replacement != "app.new" {
+		t.Fatal(replacement, ok)
+	}
+
+	var buf bytes.Buffer
+	if err := res.ExportCSV(&buf, i18n.CSVOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "app.old,,,,replaced by app.new,,old") {
+		t.Fatal(buf.String())
+	}
+
+	buf.Reset()
+	if err := res.ExportCSV(&buf, i18n.CSVOptions{OmitDeprecated: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "app.old") {
+		t.Fatal(buf.String())
+	}
+
+	var restored i18n.Resources
+	if err := restored.UnmarshalBinary(option.Must(res.MarshalBinary())); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := restored.Deprecation("app.old"); !ok {
+		t.Fatal("expected deprecation in snapshot")
+	}
+}
+
+func TestResources_DeprecateAlias(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	if err := res.Alias("app.hi", "app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.Deprecate("app.hi", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := res.Deprecation("app.hello"); !ok {
+		t.Fatal("expected deprecated key")
+	}
+
+	if _, ok := res.Deprecation("app.hi"); !ok {
+		t.Fatal("expected deprecated alias")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// EventKind describes what has changed, see [Event].
+type EventKind int8
+
+const (
+	// EventKeyAdded is emitted when a new key has been declared, e.g. by [Resources.AddString] or a catalog.
+	EventKeyAdded EventKind = iota + 1
+	// EventLanguageAdded is emitted when a new bundle has been created.
+	EventLanguageAdded
+	// EventMessageUpdated is emitted when a message of a bundle has been changed or deleted, e.g. by
+	// [Bundle.Update], [Bundle.Delete] or by loading a catalog or a [Store]. Changes of overlays are not emitted.
+	EventMessageUpdated
+	// EventPrioritiesChanged is emitted by [Resources.SetPriorities] and [Resources.SetFallbacks].
+	EventPrioritiesChanged
+	// EventFlushed is emitted by [Resources.Flush].
+	EventFlushed
+	// EventLanguageRemoved is emitted by [Resources.RemoveLanguage].
+	EventLanguageRemoved
+)
+
+// Event describes a change of a [Resources] instance. Depending on the kind, only some fields are set.
+type Event struct {
+	Kind     EventKind
+	Key      Key          // the affected key for key and message events
+	Tag      language.Tag // the affected language for language and message events
+	Old      Message      // the message before the update, which is not valid, if it was inserted
+	New      Message      // the message after the update, which is not valid, if it was deleted
+	Author   string       // see [UpdateAuthor]
+	Metadata map[string]string
+}
+
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// eventBus collects the events of a mutation while the Resources are locked and delivers them afterward, so
+// that subscribers can safely call back into the Resources. The pending events are protected by the mutex of
+// the Resources and not by the mutex of the bus.
+type eventBus struct {
+	mutex       sync.Mutex
+	lastID      int
+	subscribers []subscriber
+	active      atomic.Bool
+	pending     []Event
+}
+
+// Subscribe registers the given function which is called after each change. The function is called
+// synchronously by the mutating goroutine after all locks have been released and only with the events of its own
+// mutation. Deliveries of concurrent mutations may be interleaved. Call the returned function to unsubscribe.
+func (r *Resources) Subscribe(fn func(Event)) (unsubscribe func()) {
+	r.events.mutex.Lock()
+	defer r.events.mutex.Unlock()
+
+	r.events.lastID++
+	id := r.events.lastID
+	r.events.subscribers = append(r.events.subscribers, subscriber{id: id, fn: fn})
+	r.events.active.Store(true)
+
+	return func() {
+		r.events.mutex.Lock()
+		defer r.events.mutex.Unlock()
+
+		for i, s := range r.events.subscribers {
+			if s.id == id {
+				r.events.subscribers = append(r.events.subscribers[:i:i], r.events.subscribers[i+1:]...)
+				break
+			}
+		}
+
+		r.events.active.Store(len(r.events.subscribers) > 0)
+	}
+}
+
+// emit queues the event until the mutex is released by [Resources.unlock]. The caller must hold the mutex.
+// Events of mutations are accounted for the automatic flush, see [Resources.SetAutoFlush].
+func (r *Resources) emit(evt Event) {
+	if evt.Kind != EventFlushed {
+		r.mutated()
+	}
+
+	if !r.events.active.Load() {
+		return
+	}
+
+	r.events.pending = append(r.events.pending, evt)
+}
+
+// unlock applies a pending automatic flush, releases the mutex and delivers the events which have been
+// emitted while holding it.
+func (r *Resources) unlock() {
+	if r.flushDue() {
+		r.flush()
+	}
+
+	pending := r.events.pending
+	r.events.pending = nil
+	r.mutex.Unlock()
+
+	r.deliver(pending)
+}
+
+// publish emits the events of a mutation which has been applied without holding the mutex. The caller must not
+// hold the mutex.
+func (r *Resources) publish(evts ...Event) {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	for _, evt := range evts {
+		r.emit(evt)
+	}
+}
+
+func (r *Resources) deliver(evts []Event) {
+	if len(evts) == 0 || !r.events.active.Load() {
+		return
+	}
+
+	r.events.mutex.Lock()
+	subscribers := r.events.subscribers
+	r.events.mutex.Unlock()
+
+	for _, evt := range evts {
+		for _, s := range subscribers {
+			s.fn(evt)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_Subscribe(t *testing.T) {
+	var res i18n.Resources
+	var events []i18n.Event
+	unsubscribe := res.Subscribe(func(evt i18n.Event) {
+		events = append(events, evt)
+
+		// calling back must not deadlock
+		_ = res.Hint(evt.Key)
+	})
+
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	res.AddLanguage(language.German)
+	res.SetPriorities(language.English, language.German)
+	res.Flush()
+
+	de := res.MustMatchBundle(language.German)
+	if err := de.Update(i18n.Message{Key: "app.hello", Value: "hallo"}, i18n.UpdateAuthor("alice")); err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []i18n.EventKind
+	for _, evt := range events {
+		kinds = append(kinds, evt.Kind)
+	}
+
+	want := []i18n.EventKind{
+		i18n.EventKeyAdded,
+		i18n.EventLanguageAdded,
+		i18n.EventLanguageAdded,
+		i18n.EventPrioritiesChanged,
+		i18n.EventFlushed,
+		i18n.EventMessageUpdated,
+	}
+
+	if !slices.Equal(kinds, want) {
+		t.Fatal(kinds)
+	}
+
+	last := events[len(events)-1]
+	if last.Key != "app.hello" || last.Tag != language.German || last.Old.Valid() || last.New.Value != "hallo" || last.Author != "alice" {
+		t.Fatalf("unexpected event: %+v", last)
+	}
+
+	unsubscribe()
+	res.Flush()
+	if len(events) != len(want) {
+		t.Fatal("expected no events after unsubscribe")
+	}
+}
+
+func TestResources_SubscribeConcurrent(t *testing.T) {
+	var res i18n.Resources
+	var delivered sync.Map
+	res.Subscribe(func(evt i18n.Event) {
+		if evt.Kind == i18n.EventKeyAdded {
+			// a slow subscriber makes it likely, that the events of another goroutine are queued meanwhile
+			time.Sleep(10 * time.Microsecond)
+			delivered.Store(evt.Key, true)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				key := i18n.Key(fmt.Sprintf("app.%d.%d", g, i))
+				option.Must(res.AddString(key, i18n.Values{language.English: "x"}))
+
+				// the own events must have been delivered by the own goroutine before returning
+				if _, ok := delivered.Load(key); !ok {
+					t.Errorf("event of %v has not been delivered synchronously", key)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
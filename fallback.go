@@ -0,0 +1,95 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// SetFallbacks declares the explicit fallback chain of the given language, e.g. de-CH → de → en or
+// pt-BR → pt → es → en. If a message is missing in the matched bundle, the languages of the chain are tried
+// in order. Without an explicit chain, the CLDR parent locales are used, e.g. de-CH → de or
+// en-AU → en-001 → en. Afterward, the priorities are tried, see [Resources.SetPriorities]. An empty chain
+// removes the explicit declaration.
+func (r *Resources) SetFallbacks(tag language.Tag, chain ...language.Tag) {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if len(chain) == 0 {
+		r.fallbacks.Delete(tag)
+	} else {
+		r.fallbacks.Put(tag, slices.Clone(chain))
+	}
+
+	r.emit(Event{Kind: EventPrioritiesChanged, Tag: tag})
+}
+
+// Fallbacks returns the effective fallback chain of the given language, without the priorities.
+// See [Resources.SetFallbacks].
+func (r *Resources) Fallbacks(tag language.Tag) []language.Tag {
+	if chain, ok := r.fallbacks.Get(tag); ok {
+		return slices.Clone(chain)
+	}
+
+	var chain []language.Tag
+	for parent := tag.Parent(); parent != language.Und; parent = parent.Parent() {
+		chain = append(chain, parent)
+	}
+
+	return chain
+}
+
+// fallbackBundles returns the bundles in the order in which a missing message is looked up for the
+// given language. The matched bundle itself is not included. Bundles may be returned multiple times.
+func (r *Resources) fallbackBundles(requested, matched language.Tag) iter.Seq[*Bundle] {
+	return func(yield func(*Bundle) bool) {
+		chains := []language.Tag{requested}
+		if matched != requested {
+			chains = append(chains, matched)
+		}
+
+		for _, tag := range chains {
+			for _, fallback := range r.Fallbacks(tag) {
+				if b, ok := r.children.Get(fallback); ok {
+					if !yield(b) {
+						return
+					}
+				}
+			}
+		}
+
+		// walk over in priorities to fall through in order
+		for _, tag := range r.priorities.All() {
+			if b, ok := r.children.Get(tag); ok {
+				if !yield(b) {
+					return
+				}
+			}
+		}
+
+		// well, perhaps priorities do not match children, try again in a stable order
+		var others []*Bundle
+		for _, b := range r.children.All() {
+			others = append(others, b)
+		}
+
+		slices.SortFunc(others, func(a, b *Bundle) int {
+			return strings.Compare(a.tag.String(), b.tag.String())
+		})
+
+		for _, b := range others {
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
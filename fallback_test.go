@@ -0,0 +1,75 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_SetFallbacks(t *testing.T) {
+	deCH := language.MustParse("de-CH")
+	ptBR := language.MustParse("pt-BR")
+
+	var res i18n.Resources
+	res.SetPriorities(language.French, language.English)
+	for _, tag := range []language.Tag{language.English, language.French, language.German, deCH, language.Spanish, language.Portuguese, ptBR} {
+		res.AddLanguage(tag)
+	}
+
+	title := option.Must(res.AddString("app.title", i18n.Values{language.French: "titre", language.German: "Titel", language.Spanish: "título"}))
+	greet := option.Must(res.AddVarString("app.greet", i18n.Values{language.French: "salut {name}", language.German: "hallo {name}"}))
+	res.SetFallbacks(ptBR, language.Portuguese, language.Spanish, language.English)
+	res.Flush()
+
+	if v := res.Fallbacks(deCH); !slices.Equal(v, []language.Tag{language.German}) {
+		t.Fatal(v)
+	}
+
+	// CLDR parent instead of the priorities
+	ch := res.MustMatchBundle(deCH)
+	if v := title.Get(ch); v != "Titel" {
+		t.Fatal(v)
+	}
+
+	if v := ch.Resolve("app.greet", i18n.String("name", "Anna")); v != "hallo Anna" {
+		t.Fatal(v)
+	}
+
+	if v := ch.Resolve(greet.String(), i18n.String("name", "Anna")); v != "hallo Anna" {
+		t.Fatal(v)
+	}
+
+	// explicit chain
+	br := res.MustMatchBundle(ptBR)
+	if v := title.Get(br); v != "título" {
+		t.Fatal(v)
+	}
+
+	if v, _ := res.MatchString(ptBR, title); v != "título" {
+		t.Fatal(v)
+	}
+
+	// not in the chain, thus the priorities apply
+	if v := greet.Get(br, i18n.String("name", "Ana")); v != "salut Ana" {
+		t.Fatal(v)
+	}
+
+	restored := &i18n.Resources{}
+	if err := restored.UnmarshalBinary(option.Must(res.MarshalBinary())); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := restored.Fallbacks(ptBR); !slices.Equal(v, []language.Tag{language.Portuguese, language.Spanish, language.English}) {
+		t.Fatal(v)
+	}
+}
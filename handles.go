@@ -30,7 +30,8 @@ func (s StrHnd) Get(b Bundler) string {
 // String returns an encoded handle like @1234. This representation is cached when created through [Resources] and
 // therefore allocation free. Unknown handles may allocate their representation. This string may be used to
 // transport localized strings through legacy or standard string code. Use [Bundle.Resolve] to convert it into
-// the actual string.
+// the actual string. The number encodes the owning [Resources] instance, thus handle strings of different
+// instances never collide within the same process.
 func (s StrHnd) String() string {
 	if v, ok := strHndTable.Get(int32(s)); ok {
 		return v
@@ -39,6 +40,15 @@ func (s StrHnd) String() string {
 	return formatStrHnd(int32(s))
 }
 
+// StableString returns an encoded token like @~3w5e11264sgsf which is derived from the hash of the key instead
+// of the handle number. In contrast to [StrHnd.String], the token does not depend on the registration order
+// (and thus the package initialization order) and stays valid across builds and refactorings, as long as the key
+// is not renamed (see [Resources.Alias]). Use it for strings which are persisted, e.g. in a database.
+// [Bundle.Resolve] accepts both representations.
+func (s StrHnd) StableString() string {
+	return stableString(int32(s))
+}
+
 type VarStrHnd int32
 
 func (s VarStrHnd) localize(b *Bundle, attr ...Attr) string {
@@ -62,6 +72,11 @@ func (s VarStrHnd) String() string {
 	return formatStrHnd(int32(s))
 }
 
+// StableString returns a build-stable token like @~3w5e11264sgsf. See also [StrHnd.StableString].
+func (s VarStrHnd) StableString() string {
+	return stableString(int32(s))
+}
+
 type QStrHnd int32
 
 func (s QStrHnd) localize(b *Bundle, quantity float64, attr ...Attr) string {
@@ -84,3 +99,8 @@ func (s QStrHnd) String() string {
 
 	return formatStrHnd(int32(s))
 }
+
+// StableString returns a build-stable token like @~3w5e11264sgsf. See also [StrHnd.StableString].
+func (s QStrHnd) StableString() string {
+	return stableString(int32(s))
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// UpdateOption annotates a [Bundle.Update] with additional information for the revision log.
+type UpdateOption func(*updateOptions)
+
+type updateOptions struct {
+	author   string
+	metadata map[string]string
+}
+
+// UpdateAuthor declares who has changed the message, e.g. a user id.
+func UpdateAuthor(author string) UpdateOption {
+	return func(o *updateOptions) {
+		o.author = author
+	}
+}
+
+// UpdateMetadata attaches arbitrary caller defined information to the revision, e.g. a reason or a ticket.
+func UpdateMetadata(key, value string) UpdateOption {
+	return func(o *updateOptions) {
+		if o.metadata == nil {
+			o.metadata = map[string]string{}
+		}
+
+		o.metadata[key] = value
+	}
+}
+
+// Revision describes a single change of a message made by [Bundle.Update].
+type Revision struct {
+	ID       int64             `json:"id"`
+	Tag      language.Tag      `json:"tag"`
+	Key      Key               `json:"key"`
+	Old      Message           `json:"old,omitzero"` // not valid, if the message was inserted
+	New      Message           `json:"new"`
+	Author   string            `json:"author,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Time     time.Time         `json:"time"`
+}
+
+// A RevisionLog records the history of message changes. Implementations must be safe for concurrent use.
+type RevisionLog interface {
+	// Append assigns a new unique and ascending ID to the revision and stores it.
+	Append(rev Revision) (Revision, error)
+
+	// Revisions returns the revisions of the key and language in ascending order.
+	Revisions(tag language.Tag, key Key) ([]Revision, error)
+}
+
+// SetRevisionLog replaces the revision log. By default, an in-memory log which keeps the latest
+// [DefaultRevisionLimit] revisions is used or the attached [Store] if it also implements the RevisionLog interface,
+// like the [FileStore] does.
+func (r *Resources) SetRevisionLog(log RevisionLog) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.revisions = log
+}
+
+func (r *Resources) revisionLog() RevisionLog {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.revisions == nil {
+		r.revisions = NewMemoryRevisionLog(DefaultRevisionLimit)
+	}
+
+	return r.revisions
+}
+
+// History returns all recorded revisions of the given key or alias and language in ascending order.
+func (r *Resources) History(tag language.Tag, key Key) ([]Revision, error) {
+	return r.revisionLog().Revisions(tag, r.canonicalKey(key))
+}
+
+// persist applies the change to the attached store and records the revision. If the revision cannot be
+// recorded, the previous state of the store is restored, so that the store never contains a change without
+// a history entry.
+func (b *Bundle) persist(rev Revision, apply func(Store) error) error {
+	store := b.parent.attachedStore()
+	if store == nil {
+		if _, err := b.parent.revisionLog().Append(rev); err != nil {
+			return fmt.Errorf("cannot record revision of %v: %w", rev.Key, err)
+		}
+
+		return nil
+	}
+
+	prev, err := store.Load(b.tag, rev.Key)
+	stored := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot load persisted message %v: %w", rev.Key, err)
+	}
+
+	if err := apply(store); err != nil {
+		return fmt.Errorf("cannot persist message %v: %w", rev.Key, err)
+	}
+
+	if _, err := b.parent.revisionLog().Append(rev); err != nil {
+		var restoreErr error
+		if stored {
+			restoreErr = store.Save(b.tag, prev)
+		} else {
+			restoreErr = store.Delete(b.tag, rev.Key)
+		}
+
+		return errors.Join(fmt.Errorf("cannot record revision of %v: %w", rev.Key, err), restoreErr)
+	}
+
+	return nil
+}
+
+// Revert restores the message of the given key and language to the value which has been set by the
+// revision with the given ID. The restoration is recorded as a new revision. To undo a revision, revert to
+// its predecessor or just update the [Revision.Old] message.
+func (r *Resources) Revert(tag language.Tag, key Key, id int64, opts ...UpdateOption) error {
+	revs, err := r.History(tag, key)
+	if err != nil {
+		return err
+	}
+
+	for _, rev := range revs {
+		if rev.ID != id {
+			continue
+		}
+
+		bnd, ok := r.Bundle(tag)
+		if !ok {
+			return fmt.Errorf("no such bundle: %v", tag)
+		}
+
+		opts = append(opts, UpdateMetadata("revert", strconv.FormatInt(id, 10)))
+		if !rev.New.Valid() {
+			return bnd.Delete(key, opts...)
+		}
+
+		return bnd.Update(rev.New, opts...)
+	}
+
+	return fmt.Errorf("no such revision %d for %v [%v]: %w", id, key, tag, os.ErrNotExist)
+}
+
+// DefaultRevisionLimit is the amount of revisions which are kept by the default in-memory revision log.
+const DefaultRevisionLimit = 1000
+
+// MemoryRevisionLog keeps the latest revisions in memory.
+type MemoryRevisionLog struct {
+	mutex     sync.Mutex
+	limit     int
+	lastID    int64
+	revisions []Revision
+}
+
+// NewMemoryRevisionLog creates an empty in-memory revision log, which drops the oldest revisions of all keys
+// if more than limit revisions have been appended. A limit of zero or less keeps all revisions.
+func NewMemoryRevisionLog(limit int) *MemoryRevisionLog {
+	return &MemoryRevisionLog{limit: limit}
+}
+
+func (l *MemoryRevisionLog) Append(rev Revision) (Revision, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.lastID++
+	rev.ID = l.lastID
+	l.revisions = append(l.revisions, rev)
+	if l.limit > 0 && len(l.revisions) > l.limit {
+		l.revisions = slices.Delete(l.revisions, 0, len(l.revisions)-l.limit)
+	}
+
+	return rev, nil
+}
+
+func (l *MemoryRevisionLog) Revisions(tag language.Tag, key Key) ([]Revision, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var res []Revision
+	for _, rev := range l.revisions {
+		if rev.Tag == tag && rev.Key == key {
+			res = append(res, rev)
+		}
+	}
+
+	return res, nil
+}
+
+// Append writes the revision as a JSON line into the history.jsonl file of the store directory.
+func (s *FileStore) Append(rev Revision) (Revision, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the last id is only read once, otherwise appending would become slower with every revision
+	if s.lastID == 0 {
+		revs, err := s.readHistory()
+		if err != nil {
+			return rev, err
+		}
+
+		if len(revs) > 0 {
+			s.lastID = revs[len(revs)-1].ID
+		}
+	}
+
+	rev.ID = s.lastID + 1
+	buf, err := json.Marshal(rev)
+	if err != nil {
+		return rev, err
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return rev, err
+	}
+
+	f, err := os.OpenFile(s.historyName(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return rev, err
+	}
+
+	if _, err := f.Write(append(buf, '\n')); err != nil {
+		f.Close()
+		return rev, err
+	}
+
+	if err := f.Close(); err != nil {
+		return rev, err
+	}
+
+	s.lastID = rev.ID
+	return rev, nil
+}
+
+func (s *FileStore) Revisions(tag language.Tag, key Key) ([]Revision, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	revs, err := s.readHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Revision
+	for _, rev := range revs {
+		if rev.Tag == tag && rev.Key == key {
+			res = append(res, rev)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *FileStore) historyName() string {
+	return filepath.Join(s.dir, "history.jsonl")
+}
+
+func (s *FileStore) readHistory() ([]Revision, error) {
+	f, err := os.Open(s.historyName())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var res []Revision
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		var rev Revision
+		if err := json.Unmarshal(scanner.Bytes(), &rev); err != nil {
+			return nil, fmt.Errorf("cannot decode %s: %w", s.historyName(), err)
+		}
+
+		res = append(res, rev)
+	}
+
+	return res, scanner.Err()
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_History(t *testing.T) {
+	for _, store := range []i18n.Store{i18n.NewMemoryStore(), i18n.NewFileStore(t.TempDir())} {
+		var res i18n.Resources
+		hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+		if err := res.Attach(store); err != nil {
+			t.Fatal(err)
+		}
+
+		en := res.MustMatchBundle(language.English)
+		if err := en.Update(i18n.Message{Key: "app.hello", Value: "hi"}, i18n.UpdateAuthor("alice")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := en.Update(i18n.Message{Key: "app.hello", Value: "hey"}, i18n.UpdateAuthor("bob"), i18n.UpdateMetadata("reason", "typo")); err != nil {
+			t.Fatal(err)
+		}
+
+		revs := option.Must(res.History(language.English, "app.hello"))
+		if len(revs) != 2 {
+			t.Fatal(revs)
+		}
+
+		if revs[0].Old.Value != "hello" || revs[0].New.Value != "hi" || revs[0].Author != "alice" {
+			t.Fatal(revs[0])
+		}
+
+		if revs[1].Metadata["reason"] != "typo" {
+			t.Fatal(revs[1])
+		}
+
+		if err := res.Revert(language.English, "app.hello", revs[0].ID, i18n.UpdateAuthor("carol")); err != nil {
+			t.Fatal(err)
+		}
+
+		if v := hello.Get(en); v != "hi" {
+			t.Fatal(v)
+		}
+
+		revs = option.Must(res.History(language.English, "app.hello"))
+		if len(revs) != 3 || revs[2].Author != "carol" || revs[2].Metadata["revert"] == "" {
+			t.Fatal(revs)
+		}
+
+		if err := res.Revert(language.English, "app.hello", 42); err == nil {
+			t.Fatal("expected unknown revision")
+		}
+	}
+}
+
+type failingRevisionLog struct{}
+
+func (failingRevisionLog) Append(rev i18n.Revision) (i18n.Revision, error) {
+	return rev, errors.New("disk full")
+}
+
+func (failingRevisionLog) Revisions(language.Tag, i18n.Key) ([]i18n.Revision, error) {
+	return nil, nil
+}
+
+func TestResources_HistoryConsistency(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	if err := res.Alias("app.hi", "app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	log := i18n.NewMemoryRevisionLog(2)
+	res.SetRevisionLog(log)
+
+	en := res.MustMatchBundle(language.English)
+	for _, v := range []string{"a", "b", "c"} {
+		if err := en.Update(i18n.Message{Key: "app.hi", Value: v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the log is capped and aliases resolve to the declared key
+	revs := option.Must(res.History(language.English, "app.hi"))
+	if len(revs) != 2 || revs[0].New.Value != "b" || revs[1].Key != "app.hello" {
+		t.Fatal(revs)
+	}
+
+	store := i18n.NewMemoryStore()
+	if err := res.Attach(store); err != nil {
+		t.Fatal(err)
+	}
+
+	res.SetRevisionLog(failingRevisionLog{})
+	if err := en.Update(i18n.Message{Key: "app.hello", Value: "d"}); err == nil {
+		t.Fatal("expected revision error")
+	}
+
+	if _, err := store.Load(language.English, "app.hello"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected rolled back store", err)
+	}
+
+	if v := en.Resolve("app.hello"); v != "c" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_HistoryConcurrentUpdates(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	if err := res.Attach(i18n.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	res.SetRevisionLog(i18n.NewMemoryRevisionLog(1000))
+	en := res.MustMatchBundle(language.English)
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := en.Update(i18n.Message{Key: "app.hello", Value: strconv.Itoa(i)}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// each revision must start from the message of its predecessor
+	revs := option.Must(res.History(language.English, "app.hello"))
+	for i := 1; i < len(revs); i++ {
+		if revs[i].Old.Value != revs[i-1].New.Value {
+			t.Fatal(i, revs[i-1], revs[i])
+		}
+	}
+
+	if v := en.MessageByKey("app.hello").Value; v != revs[len(revs)-1].New.Value {
+		t.Fatal(v)
+	}
+}
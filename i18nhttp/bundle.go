@@ -0,0 +1,182 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18nhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/i18n/parser"
+)
+
+// BundleJSON is the response of the [BundleHandler].
+type BundleJSON struct {
+	Tag      string                   `json:"tag"`
+	Messages map[i18n.Key]MessageJSON `json:"messages"`
+}
+
+// MessageJSON contains the raw template and its tokens of a single message, so that a frontend can interpolate
+// the variables in the same way as [i18n.Template] does.
+type MessageJSON struct {
+	Kind       string                  `json:"kind"` // one of string, var or quantities
+	Value      string                  `json:"value,omitempty"`
+	Tokens     []TokenJSON             `json:"tokens,omitempty"`
+	Quantities map[string]TemplateJSON `json:"quantities,omitempty"` // plural categories like one or other
+	Tag        string                  `json:"tag,omitempty"`        // the providing language, if it is a fallback
+	Deprecated bool                    `json:"deprecated,omitempty"`
+}
+
+// TemplateJSON is a single raw template and its tokens.
+type TemplateJSON struct {
+	Value  string      `json:"value"`
+	Tokens []TokenJSON `json:"tokens,omitempty"`
+}
+
+// TokenJSON is either a literal text or a variable name, see [parser.Token].
+type TokenJSON struct {
+	Text string `json:"text,omitempty"`
+	Var  string `json:"var,omitempty"`
+}
+
+// BundleHandler serves the effective messages of the negotiated language (see [Negotiate]) as [BundleJSON],
+// including the fallback messages of other languages. The optional query parameter prefix restricts the keys to
+// a namespace, e.g. ?prefix=billing (see [i18n.Resources.Namespace]). Deprecated keys are marked. The response
+// carries an ETag derived from the bundle versions, so that clients can revalidate cheaply using If-None-Match.
+func BundleHandler(opts Options) http.Handler {
+	opts = opts.withDefaults()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		bnd, ok := Negotiate(r, opts)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		prefix := strings.Trim(r.URL.Query().Get("prefix"), ".")
+		etag := bundleETag(opts.Resources, bnd, prefix)
+
+		for _, v := range opts.vary() {
+			w.Header().Add("Vary", v)
+		}
+
+		w.Header().Set("Content-Language", bnd.Tag().String())
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", etag)
+
+		if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		buf, err := json.Marshal(newBundleJSON(opts.Resources, bnd, prefix))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
+		w.Write(buf)
+	})
+}
+
+// bundleETag combines the content hash of the entire instance with the request, because the fallback messages
+// of the given bundle may come from any other bundle. The hash is equal across processes with the same content.
+func bundleETag(res *i18n.Resources, bnd *i18n.Bundle, prefix string) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", bnd.Tag(), prefix, res.Hash())
+	return `"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+func newBundleJSON(res *i18n.Resources, bnd *i18n.Bundle, prefix string) BundleJSON {
+	doc := BundleJSON{
+		Tag:      bnd.Tag().String(),
+		Messages: map[i18n.Key]MessageJSON{},
+	}
+
+	for _, key := range res.SortedKeys() {
+		if prefix != "" && !strings.HasPrefix(string(key), prefix+".") {
+			continue
+		}
+
+		origin, ok := bnd.Origin(key)
+		if !ok {
+			continue
+		}
+
+		msg := origin.MessageByKey(key)
+		m := MessageJSON{}
+		if origin != bnd {
+			m.Tag = origin.Tag().String()
+		}
+
+		_, m.Deprecated = res.Deprecation(key)
+
+		m.Kind = kindName(msg.Kind)
+		switch msg.Kind {
+		case i18n.MessageString:
+			m.Value = msg.Value
+		case i18n.MessageVarString:
+			m.Value = msg.Value
+			m.Tokens = tokensOf(msg.Value)
+		case i18n.MessageQuantities:
+			m.Quantities = map[string]TemplateJSON{}
+			for category, value := range msg.Quantities.All() {
+				m.Quantities[category] = TemplateJSON{Value: value, Tokens: tokensOf(value)}
+			}
+		default:
+			continue
+		}
+
+		doc.Messages[key] = m
+	}
+
+	return doc
+}
+
+func tokensOf(text string) []TokenJSON {
+	tokens, err := parser.Parse(text)
+	if err != nil {
+		// cannot happen, because the message has already been validated
+		return nil
+	}
+
+	res := make([]TokenJSON, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Type == parser.VarToken {
+			res = append(res, TokenJSON{Var: token.Value})
+		} else {
+			res = append(res, TokenJSON{Text: token.Value})
+		}
+	}
+
+	return res
+}
+
+func kindName(kind i18n.MessageType) string {
+	switch kind {
+	case i18n.MessageString:
+		return "string"
+	case i18n.MessageVarString:
+		return "var"
+	case i18n.MessageQuantities:
+		return "quantities"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18nhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/i18n/i18nhttp"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestBundleHandler(t *testing.T) {
+	var res i18n.Resources
+	res.SetPriorities(language.English, language.German)
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	option.Must(res.AddVarString("app.greet", i18n.Values{language.English: "hi {name}"}))
+	option.Must(res.AddQuantityString("shop.apples", i18n.QValues{language.German: {One: "ein Apfel", Other: "{n} Äpfel"}}))
+	if err := res.Deprecate("app.hello", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	res.Flush()
+
+	handler := i18nhttp.BundleHandler(i18nhttp.Options{Resources: &res})
+
+	get := func(target, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/?lang=de", "")
+	if rec.Code != http.StatusOK {
+		t.Fatal(rec.Code)
+	}
+
+	var doc i18nhttp.BundleJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Tag != "de" || len(doc.Messages) != 3 {
+		t.Fatalf("unexpected bundle: %+v", doc)
+	}
+
+	if m := doc.Messages["app.hello"]; m.Value != "hallo" || !m.Deprecated || m.Tag != "" {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	if m := doc.Messages["app.greet"]; m.Kind != "var" || m.Tag != "en" || len(m.Tokens) != 2 || m.Tokens[1].Var != "name" {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	if m := doc.Messages["shop.apples"]; m.Kind != "quantities" || m.Quantities["other"].Tokens[0].Var != "n" {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	etag := rec.Header().Get("ETag")
+	if rec := get("/?lang=de", etag); rec.Code != http.StatusNotModified {
+		t.Fatal(rec.Code)
+	}
+
+	// the fallback language has changed
+	en := res.MustMatchBundle(language.English)
+	if err := en.Update(i18n.Message{Key: "app.greet", Value: "hey {name}"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := get("/?lang=de", etag); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code)
+	}
+
+	rec = get("/?lang=de&prefix=shop", "")
+	doc = i18nhttp.BundleJSON{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Messages) != 1 {
+		t.Fatalf("unexpected bundle: %+v", doc)
+	}
+}
@@ -0,0 +1,531 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18nhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/worldiety/i18n"
+	"golang.org/x/text/language"
+)
+
+// ErrForbidden may be returned by [EditorOptions.Authorize] to reject a request.
+var ErrForbidden = errors.New("forbidden")
+
+// EditorOptions configure the [EditorHandler].
+type EditorOptions struct {
+	// Resources to edit. Defaults to [i18n.Default].
+	Resources *i18n.Resources
+
+	// Authorize is called before each request. Write is true for all mutating requests. The returned author is
+	// recorded in the revision history, see [i18n.UpdateAuthor]. Any error rejects the request with
+	// status 403. If nil, only reading requests are permitted.
+	Authorize func(r *http.Request, write bool) (author string, err error)
+
+	// MaxBodySize limits the size of a request body in bytes. Larger bodies are rejected with status 413.
+	// Defaults to [DefaultMaxBodySize].
+	MaxBodySize int64
+}
+
+// DefaultMaxBodySize is the default limit of [EditorOptions.MaxBodySize].
+const DefaultMaxBodySize = 1 << 20
+
+// KeyJSON describes a declared key.
+type KeyJSON struct {
+	Key         i18n.Key       `json:"key"`
+	Kind        string         `json:"kind"` // one of string, var or quantities
+	Hint        string         `json:"hint,omitempty"`
+	VarHints    []i18n.VarHint `json:"varHints,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+	Replacement i18n.Key       `json:"replacement,omitempty"`
+	Aliases     []i18n.Key     `json:"aliases,omitempty"`
+}
+
+// EditorMessageJSON is a message of a language together with its resolution state.
+type EditorMessageJSON struct {
+	Message  i18n.Message `json:"message"`
+	Missing  bool         `json:"missing,omitempty"`  // true, if the language does not contain the message itself
+	Fallback string       `json:"fallback,omitempty"` // the providing language, if the message is missing
+}
+
+// DeprecationJSON is the request body to deprecate a key, see [i18n.Resources.Deprecate].
+type DeprecationJSON struct {
+	Replacement i18n.Key `json:"replacement,omitempty"`
+}
+
+// NewLanguageJSON is the request body to add a language.
+type NewLanguageJSON struct {
+	Tag string `json:"tag"`
+}
+
+// EditorHandler provides a JSON REST API to build translation editors. Mount it using [http.StripPrefix].
+//
+//	GET    /keys?prefix=billing                      lists all keys as KeyJSON
+//	GET    /keys/{key}                               returns the KeyJSON
+//	DELETE /keys/{key}                               deletes the messages of the key in all languages
+//	PUT    /keys/{key}/hint                          replaces the hint from a JSON string
+//	PUT    /keys/{key}/varhints                      replaces the var hints from a JSON array
+//	PUT    /keys/{key}/deprecation                   deprecates the key from DeprecationJSON
+//	POST   /keys/{key}/aliases                       declares an alias of the key from a JSON string
+//	DELETE /keys/{key}/aliases/{alias}               removes the alias
+//	GET    /languages                                lists all language tags
+//	POST   /languages                                adds a language from NewLanguageJSON
+//	DELETE /languages/{tag}                          removes the language
+//	GET    /languages/{tag}/messages?missing=true    lists the messages as EditorMessageJSON
+//	GET    /languages/{tag}/messages/{key}           returns the EditorMessageJSON
+//	PUT    /languages/{tag}/messages/{key}           updates the message from an i18n.Message
+//	DELETE /languages/{tag}/messages/{key}           deletes the message
+//
+// The message list supports the filters prefix, missing=true (messages the language does not contain itself)
+// and fallback=true (missing messages which are provided by another language). Keys cannot be removed, because
+// their handles may still be in use, thus deleting a key only clears its messages and a client should deprecate
+// it as well.
+//
+// Keys cannot be declared, because they are defined by the application code or its catalogs. Only message updates
+// and deletions are persisted by an attached [i18n.Store]. Hints, var hints, deprecations, aliases and languages
+// are only changed in memory and do not survive a restart, unless the application declares them as well.
+//
+// The handler does not flush the Resources after each change, which would copy all buffers on every request.
+// Use [i18n.Resources.SetAutoFlush] to flush the changes of an editing session in batches.
+func EditorHandler(opts EditorOptions) http.Handler {
+	if opts.Resources == nil {
+		opts.Resources = i18n.Default
+	}
+
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+
+	e := &editor{res: opts.Resources, authorize: opts.Authorize, maxBodySize: opts.MaxBodySize}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /keys", e.read(e.listKeys))
+	mux.HandleFunc("GET /keys/{key}", e.read(e.getKey))
+	mux.HandleFunc("DELETE /keys/{key}", e.write(e.deleteKey))
+	mux.HandleFunc("PUT /keys/{key}/hint", e.write(e.setHint))
+	mux.HandleFunc("PUT /keys/{key}/varhints", e.write(e.setVarHints))
+	mux.HandleFunc("PUT /keys/{key}/deprecation", e.write(e.deprecateKey))
+	mux.HandleFunc("POST /keys/{key}/aliases", e.write(e.addAlias))
+	mux.HandleFunc("DELETE /keys/{key}/aliases/{alias}", e.write(e.removeAlias))
+	mux.HandleFunc("GET /languages", e.read(e.listLanguages))
+	mux.HandleFunc("POST /languages", e.write(e.addLanguage))
+	mux.HandleFunc("DELETE /languages/{tag}", e.write(e.removeLanguage))
+	mux.HandleFunc("GET /languages/{tag}/messages", e.read(e.listMessages))
+	mux.HandleFunc("GET /languages/{tag}/messages/{key}", e.read(e.getMessage))
+	mux.HandleFunc("PUT /languages/{tag}/messages/{key}", e.write(e.updateMessage))
+	mux.HandleFunc("DELETE /languages/{tag}/messages/{key}", e.write(e.deleteMessage))
+
+	return mux
+}
+
+// httpError is an error with an associated status code.
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e httpError) Error() string {
+	return e.err.Error()
+}
+
+func statusError(status int, format string, args ...any) error {
+	return httpError{status: status, err: fmt.Errorf(format, args...)}
+}
+
+type editor struct {
+	res         *i18n.Resources
+	authorize   func(r *http.Request, write bool) (string, error)
+	maxBodySize int64
+}
+
+type editorFunc func(r *http.Request, author string) (any, error)
+
+func (e *editor) read(fn editorFunc) http.HandlerFunc {
+	return e.serve(fn, false)
+}
+
+func (e *editor) write(fn editorFunc) http.HandlerFunc {
+	return e.serve(fn, true)
+}
+
+func (e *editor) serve(fn editorFunc, write bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var author string
+		switch {
+		case e.authorize != nil:
+			a, err := e.authorize(r, write)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+
+			author = a
+		case write:
+			http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, e.maxBodySize)
+		res, err := fn(r, author)
+		if err != nil {
+			status := http.StatusBadRequest
+			var herr httpError
+			if errors.As(err, &herr) {
+				status = herr.status
+			}
+
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		if res == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		buf, err := json.Marshal(res)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buf)
+	}
+}
+
+func decode[T any](r *http.Request) (T, error) {
+	var v T
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return v, statusError(http.StatusRequestEntityTooLarge, "request body exceeds %d bytes", maxErr.Limit)
+		}
+
+		return v, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	return v, nil
+}
+
+func (e *editor) key(r *http.Request) (i18n.Key, error) {
+	key := i18n.Key(r.PathValue("key"))
+	if e.res.MessageType(key) == i18n.MessageUndefined {
+		return "", statusError(http.StatusNotFound, "no such key: %v", key)
+	}
+
+	return key, nil
+}
+
+func (e *editor) bundle(r *http.Request) (*i18n.Bundle, error) {
+	tag, err := language.Parse(r.PathValue("tag"))
+	if err != nil {
+		return nil, statusError(http.StatusNotFound, "invalid language: %w", err)
+	}
+
+	bnd, ok := e.res.Bundle(tag)
+	if !ok {
+		return nil, statusError(http.StatusNotFound, "no such language: %v", tag)
+	}
+
+	return bnd, nil
+}
+
+func (e *editor) keyJSON(key i18n.Key) KeyJSON {
+	k := KeyJSON{
+		Key:      key,
+		Kind:     kindName(e.res.MessageType(key)),
+		Hint:     e.res.Hint(key),
+		VarHints: slices.Collect(e.res.VarHints(key)),
+		Aliases:  e.res.AliasesOf(key),
+	}
+
+	k.Replacement, k.Deprecated = e.res.Deprecation(key)
+	return k
+}
+
+func (e *editor) listKeys(r *http.Request, _ string) (any, error) {
+	prefix := strings.Trim(r.URL.Query().Get("prefix"), ".")
+	keys := []KeyJSON{}
+	for _, key := range e.res.SortedKeys() {
+		if prefix == "" || strings.HasPrefix(string(key), prefix+".") {
+			keys = append(keys, e.keyJSON(key))
+		}
+	}
+
+	return keys, nil
+}
+
+func (e *editor) getKey(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) deleteKey(r *http.Request, author string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bnd := range e.res.All() {
+		if err := bnd.Delete(key, i18n.UpdateAuthor(author)); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (e *editor) deprecateKey(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := decode[DeprecationJSON](r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.res.Deprecate(key, req.Replacement); err != nil {
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) addAlias(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := decode[i18n.Key](r)
+	if err != nil {
+		return nil, err
+	}
+
+	if alias == "" {
+		return nil, fmt.Errorf("alias must not be empty")
+	}
+
+	if err := e.res.Alias(alias, key); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, httpError{status: http.StatusConflict, err: err}
+		}
+
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) removeAlias(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	alias := i18n.Key(r.PathValue("alias"))
+	if !slices.Contains(e.res.AliasesOf(key), alias) {
+		return nil, statusError(http.StatusNotFound, "no such alias of %v: %v", key, alias)
+	}
+
+	if err := e.res.RemoveAlias(alias); err != nil {
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) setHint(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hint, err := decode[string](r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.res.SetHint(key, hint); err != nil {
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) setVarHints(r *http.Request, _ string) (any, error) {
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hints, err := decode[[]i18n.VarHint](r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.res.SetVarHints(key, hints); err != nil {
+		return nil, err
+	}
+
+	return e.keyJSON(key), nil
+}
+
+func (e *editor) listLanguages(*http.Request, string) (any, error) {
+	tags := []string{}
+	for _, tag := range e.res.Tags() {
+		tags = append(tags, tag.String())
+	}
+
+	return tags, nil
+}
+
+func (e *editor) addLanguage(r *http.Request, _ string) (any, error) {
+	req, err := decode[NewLanguageJSON](r)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := language.Parse(req.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid language: %w", err)
+	}
+
+	if _, ok := e.res.AddLanguage(tag); !ok {
+		return nil, statusError(http.StatusConflict, "language already exists: %v", tag)
+	}
+
+	return NewLanguageJSON{Tag: tag.String()}, nil
+}
+
+func (e *editor) removeLanguage(r *http.Request, _ string) (any, error) {
+	bnd, err := e.bundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.res.RemoveLanguage(bnd.Tag()); err != nil {
+		return nil, httpError{status: http.StatusConflict, err: err}
+	}
+
+	return nil, nil
+}
+
+func (e *editor) messageJSON(bnd *i18n.Bundle, key i18n.Key) EditorMessageJSON {
+	m := EditorMessageJSON{Message: bnd.MessageByKey(key)}
+	if !m.Message.Valid() {
+		m.Missing = true
+		m.Message.Kind = e.res.MessageType(key)
+		if origin, ok := bnd.Origin(key); ok && origin != bnd {
+			m.Fallback = origin.Tag().String()
+		}
+	}
+
+	return m
+}
+
+func (e *editor) listMessages(r *http.Request, _ string) (any, error) {
+	bnd, err := e.bundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.URL.Query()
+	prefix := strings.Trim(query.Get("prefix"), ".")
+	missing := query.Get("missing") == "true"
+	fallback := query.Get("fallback") == "true"
+
+	msgs := []EditorMessageJSON{}
+	for _, key := range e.res.SortedKeys() {
+		if prefix != "" && !strings.HasPrefix(string(key), prefix+".") {
+			continue
+		}
+
+		m := e.messageJSON(bnd, key)
+		if missing && !m.Missing {
+			continue
+		}
+
+		if fallback && m.Fallback == "" {
+			continue
+		}
+
+		msgs = append(msgs, m)
+	}
+
+	return msgs, nil
+}
+
+func (e *editor) getMessage(r *http.Request, _ string) (any, error) {
+	bnd, err := e.bundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.messageJSON(bnd, key), nil
+}
+
+func (e *editor) updateMessage(r *http.Request, author string) (any, error) {
+	bnd, err := e.bundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decode[i18n.Message](r)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Key = key
+	if err := bnd.Update(msg, i18n.UpdateAuthor(author)); err != nil {
+		return nil, err
+	}
+
+	return e.messageJSON(bnd, key), nil
+}
+
+func (e *editor) deleteMessage(r *http.Request, author string) (any, error) {
+	bnd, err := e.bundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.key(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bnd.Delete(key, i18n.UpdateAuthor(author)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18nhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/i18n/i18nhttp"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestEditorHandler(t *testing.T) {
+	var res i18n.Resources
+	res.SetPriorities(language.English, language.German)
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	option.Must(res.AddVarString("app.greet", i18n.Values{language.English: "hi {name}"}, i18n.LocalizationHint("greeting")))
+	res.Flush()
+
+	handler := i18nhttp.EditorHandler(i18nhttp.EditorOptions{
+		Resources: &res,
+		Authorize: func(r *http.Request, write bool) (string, error) {
+			if write && r.Header.Get("Authorization") != "editor" {
+				return "", i18nhttp.ErrForbidden
+			}
+
+			return "alice", nil
+		},
+	})
+
+	do := func(method, target, body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "editor")
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var msgs []i18nhttp.EditorMessageJSON
+	rec := do(http.MethodGet, "/languages/de/messages?fallback=true", "", false)
+	if err := json.Unmarshal(rec.Body.Bytes(), &msgs); err != nil {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if len(msgs) != 1 || msgs[0].Message.Key != "app.greet" || !msgs[0].Missing || msgs[0].Fallback != "en" {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	}
+
+	if rec := do(http.MethodPut, "/languages/de/messages/app.greet", `{"value":"hallo {name}"}`, false); rec.Code != http.StatusForbidden {
+		t.Fatal(rec.Code)
+	}
+
+	if rec := do(http.MethodPut, "/languages/de/messages/app.greet", `{"value":"hallo {name"}`, true); rec.Code != http.StatusBadRequest {
+		t.Fatal(rec.Code)
+	}
+
+	if rec := do(http.MethodPut, "/languages/de/messages/app.greet", `{"value":"hallo {name}"}`, true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	de := res.MustMatchBundle(language.German)
+	if v := de.Resolve("app.greet", i18n.String("name", "Anna")); v != "hallo Anna" {
+		t.Fatal(v)
+	}
+
+	revs := option.Must(res.History(language.German, "app.greet"))
+	if len(revs) != 1 || revs[0].Author != "alice" {
+		t.Fatalf("unexpected history: %+v", revs)
+	}
+
+	if rec := do(http.MethodPut, "/keys/app.greet/varhints", `[{"name":"name","description":"first name"}]`, true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	var key i18nhttp.KeyJSON
+	rec = do(http.MethodGet, "/keys/app.greet", "", false)
+	if err := json.Unmarshal(rec.Body.Bytes(), &key); err != nil {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if key.Kind != "var" || key.Hint != "greeting" || len(key.VarHints) != 1 || key.VarHints[0].Description != "first name" {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+
+	if rec := do(http.MethodPost, "/keys", `{"key":"app.new","kind":"string"}`, true); rec.Code != http.StatusMethodNotAllowed {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if rec := do(http.MethodPost, "/languages", `{"tag":"fr"}`, true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if _, ok := res.Bundle(language.French); !ok {
+		t.Fatal("expected new language")
+	}
+
+	if rec := do(http.MethodDelete, "/languages/de/messages/app.hello", "", true); rec.Code != http.StatusNoContent {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if rec := do(http.MethodGet, "/keys/app.unknown", "", false); rec.Code != http.StatusNotFound {
+		t.Fatal(rec.Code)
+	}
+
+	if rec := do(http.MethodPut, "/keys/app.hello/deprecation", `{"replacement":"app.greet"}`, true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if replacement, ok := res.Deprecation("app.hello"); !ok || replacement != "app.greet" {
+		t.Fatal("expected deprecation", replacement)
+	}
+
+	if rec := do(http.MethodPost, "/keys/app.greet/aliases", `"app.greeting"`, true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if rec := do(http.MethodPost, "/keys/app.greet/aliases", `"app.hello"`, true); rec.Code != http.StatusConflict {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if v := de.Resolve("app.greeting", i18n.String("name", "Anna")); v != "hallo Anna" {
+		t.Fatal(v)
+	}
+
+	if rec := do(http.MethodDelete, "/keys/app.greet/aliases/app.greeting", "", true); rec.Code != http.StatusOK {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if v := res.AliasesOf("app.greet"); len(v) != 0 {
+		t.Fatal(v)
+	}
+
+	if rec := do(http.MethodDelete, "/keys/app.greet", "", true); rec.Code != http.StatusNoContent {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if v := de.Resolve("app.greet"); v != "app.greet" {
+		t.Fatal("expected deleted messages", v)
+	}
+
+	if rec := do(http.MethodDelete, "/languages/fr", "", true); rec.Code != http.StatusNoContent {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+
+	if _, ok := res.Bundle(language.French); ok {
+		t.Fatal("expected removed language")
+	}
+
+	large := `"` + strings.Repeat("x", i18nhttp.DefaultMaxBodySize) + `"`
+	if rec := do(http.MethodPut, "/keys/app.hello/hint", large, true); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatal(rec.Code)
+	}
+}
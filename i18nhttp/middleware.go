@@ -0,0 +1,162 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+// Package i18nhttp integrates the i18n package with net/http.
+package i18nhttp
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/worldiety/i18n"
+	"golang.org/x/text/language"
+)
+
+// Source of a language preference within a request.
+type Source int
+
+const (
+	// Query evaluates a query parameter like ?lang=de-CH, see [Options.QueryParam].
+	Query Source = iota + 1
+	// Cookie evaluates a cookie, see [Options.CookieName].
+	Cookie
+	// Header evaluates the Accept-Language header.
+	Header
+)
+
+// Options configure the language negotiation.
+type Options struct {
+	// Resources to negotiate with. Defaults to [i18n.Default].
+	Resources *i18n.Resources
+
+	// Order of the evaluated sources. The first source which provides a matching language wins. Defaults to
+	// Query, Cookie and Header.
+	Order []Source
+
+	// QueryParam is the name of the query parameter. Defaults to lang.
+	QueryParam string
+
+	// CookieName is the name of the cookie. Defaults to lang.
+	CookieName string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Resources == nil {
+		o.Resources = i18n.Default
+	}
+
+	if len(o.Order) == 0 {
+		o.Order = []Source{Query, Cookie, Header}
+	}
+
+	if o.QueryParam == "" {
+		o.QueryParam = "lang"
+	}
+
+	if o.CookieName == "" {
+		o.CookieName = "lang"
+	}
+
+	return o
+}
+
+// vary returns the request headers which influence the negotiation.
+func (o Options) vary() []string {
+	var vary []string
+	if slices.Contains(o.Order, Header) {
+		vary = append(vary, "Accept-Language")
+	}
+
+	if slices.Contains(o.Order, Cookie) {
+		vary = append(vary, "Cookie")
+	}
+
+	return vary
+}
+
+// Middleware negotiates the language of each request (see [Negotiate]) and places the matched [i18n.Bundle]
+// into the request context, see [i18n.BundleFrom]. It sets the Content-Language header and adds the evaluated
+// headers to the Vary header, so that caches keep the variants apart.
+func Middleware(opts Options) func(http.Handler) http.Handler {
+	opts = opts.withDefaults()
+	vary := opts.vary()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, v := range vary {
+				w.Header().Add("Vary", v)
+			}
+
+			bnd, ok := Negotiate(r, opts)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Language", bnd.Tag().String())
+			next.ServeHTTP(w, r.WithContext(i18n.WithBundle(r.Context(), bnd)))
+		})
+	}
+}
+
+// Negotiate evaluates the sources of the request in the configured order and returns the bundle of the first
+// matching language. If nothing matches, the bundle of the first prioritized language is returned. Returns false
+// only if the resources contain no language at all.
+func Negotiate(r *http.Request, opts Options) (*i18n.Bundle, bool) {
+	opts = opts.withDefaults()
+	res := opts.Resources
+
+	for _, src := range opts.Order {
+		for _, tag := range preferences(r, src, opts) {
+			if matched, ok := res.MatchTag(tag); ok {
+				if bnd, ok := res.Bundle(matched); ok {
+					return bnd, true
+				}
+			}
+		}
+	}
+
+	// priorities may be incomplete, if nothing has been matched yet
+	for _, tag := range slices.Concat(slices.Collect(res.Priorities()), res.Tags()) {
+		if bnd, ok := res.Bundle(tag); ok {
+			return bnd, true
+		}
+	}
+
+	return nil, false
+}
+
+// preferences returns the requested languages of the source in descending preference.
+func preferences(r *http.Request, src Source, opts Options) []language.Tag {
+	var value string
+	switch src {
+	case Query:
+		value = r.URL.Query().Get(opts.QueryParam)
+	case Cookie:
+		if c, err := r.Cookie(opts.CookieName); err == nil {
+			value = c.Value
+		}
+	case Header:
+		tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		if err != nil {
+			return nil
+		}
+
+		return tags
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil {
+		return nil
+	}
+
+	return []language.Tag{tag}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18nhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/i18n/i18nhttp"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestMiddleware(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{
+		language.English: "hello",
+		language.German:  "hallo",
+		language.French:  "salut",
+	}))
+	res.SetPriorities(language.English)
+	res.Flush()
+
+	handler := i18nhttp.Middleware(i18nhttp.Options{Resources: &res})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bnd, ok := i18n.BundleFrom(r.Context())
+		if !ok {
+			t.Fatal("expected bundle")
+		}
+
+		w.Write([]byte(hello.Get(bnd)))
+	}))
+
+	tests := []struct {
+		name   string
+		target string
+		cookie string
+		header string
+		want   string
+	}{
+		{name: "default", target: "/", want: "hello"},
+		{name: "header", target: "/", header: "fr-CH, de;q=0.8", want: "salut"},
+		{name: "header unsupported", target: "/", header: "ja", want: "hello"},
+		{name: "cookie before header", target: "/", cookie: "de", header: "fr", want: "hallo"},
+		{name: "query before cookie", target: "/?lang=fr", cookie: "de", want: "salut"},
+		{name: "invalid query", target: "/?lang=%%%", cookie: "de", want: "hallo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tt.want {
+				t.Fatal(rec.Body.String())
+			}
+
+			if rec.Header().Get("Content-Language") == "" {
+				t.Fatal("expected Content-Language")
+			}
+
+			if v := rec.Header().Values("Vary"); len(v) != 2 {
+				t.Fatal(v)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"errors"
+	"sync"
+	"weak"
+)
+
+// A handle encodes the owning Resources instance in its upper bits and the slot within the bundles in its lower
+// bits, so that handle strings like @1234 are unique within the process even if multiple Resources exist.
+// The Default instance always owns the first instance slot, thus its handles are just the slot numbers.
+// Handles are int32 values as part of the public API, therefore the 31 usable bits are split into 11 bits for
+// 2048 instances and 20 bits for 1,048,575 keys per instance. Even large applications declare some ten thousand
+// keys, and a process which needs more keys can split them into multiple instances.
+const (
+	hndSlotBits  = 20
+	hndSlotMask  = 1<<hndSlotBits - 1
+	maxInstances = 1 << (31 - hndSlotBits)
+)
+
+// ErrTooManyKeys is returned when a key is added to a [Resources] instance, which has already allocated all
+// of its handles.
+var ErrTooManyKeys = errors.New("too many keys in i18n.Resources")
+
+// ErrTooManyInstances is returned when a key is added to a [Resources] instance, while 2048 other instances,
+// which own keys, are alive. Instances without keys do not count.
+var ErrTooManyInstances = errors.New("too many living i18n.Resources instances")
+
+// instances is the registry of all living Resources. The instance number of a Resources is the index+1 into refs
+// and 0 means not yet registered. Clones share the instance number of their origin, thus each slot holds all
+// instances with the same number. Slots whose instances have all been garbage collected are reused.
+var instances struct {
+	mutex sync.Mutex
+	refs  [][]weak.Pointer[Resources]
+}
+
+func init() {
+	// reserve the first slot, so that the handles of Default are stable and independent of other instances
+	_, _ = Default.registerInstance()
+}
+
+// registerInstance returns the instance number and registers the instance lazily. It is only called when handles are
+// allocated, so that instances which are just read, never occupy an instance slot.
+func (r *Resources) registerInstance() (int32, error) {
+	if id := r.instanceID.Load(); id != 0 {
+		return id, nil
+	}
+
+	instances.mutex.Lock()
+	defer instances.mutex.Unlock()
+
+	// double check
+	if id := r.instanceID.Load(); id != 0 {
+		return id, nil
+	}
+
+	refs := []weak.Pointer[Resources]{weak.Make(r)}
+	for i, slot := range instances.refs {
+		if firstAlive(slot) == nil {
+			// the handle strings of the collected instance must not resolve to the keys of the new one
+			owned := func(hnd int32, _ string) bool { return int(hnd>>hndSlotBits) == i }
+			strHndTable.DeleteFunc(owned)
+			stableHndTable.DeleteFunc(owned)
+
+			instances.refs[i] = refs
+			r.instanceID.Store(int32(i + 1))
+			return int32(i + 1), nil
+		}
+	}
+
+	if len(instances.refs) >= maxInstances {
+		return 0, ErrTooManyInstances
+	}
+
+	instances.refs = append(instances.refs, refs)
+	r.instanceID.Store(int32(len(instances.refs)))
+	return int32(len(instances.refs)), nil
+}
+
+// shareInstance lets the clone own the handles of r. An unregistered instance has no handles to share.
+func (r *Resources) shareInstance(clone *Resources) {
+	id := r.instanceID.Load()
+	if id == 0 {
+		return
+	}
+
+	instances.mutex.Lock()
+	defer instances.mutex.Unlock()
+
+	instances.refs[id-1] = append(instances.refs[id-1], weak.Make(clone))
+	clone.instanceID.Store(id)
+}
+
+func firstAlive(refs []weak.Pointer[Resources]) *Resources {
+	for _, ref := range refs {
+		if r := ref.Value(); r != nil {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// lookupInstance returns the living Resources which owns the given handle.
+func lookupInstance(hnd int32) (*Resources, bool) {
+	idx := int(hnd >> hndSlotBits)
+
+	instances.mutex.Lock()
+	defer instances.mutex.Unlock()
+
+	if hnd < 0 || idx >= len(instances.refs) {
+		return nil, false
+	}
+
+	r := firstAlive(instances.refs[idx])
+	return r, r != nil
+}
+
+// handle returns the handle of the given bundle slot. The instance must have been registered, which is the case
+// for each instance with allocated slots.
+func (r *Resources) handle(slot int) int32 {
+	return (r.instanceID.Load()-1)<<hndSlotBits | int32(slot)
+}
+
+// owns returns true, if the given handle has been allocated by this instance or by the instance it was cloned from.
+func (r *Resources) owns(hnd int32) bool {
+	id := r.instanceID.Load()
+	return id != 0 && hnd >= 0 && hnd>>hndSlotBits == id-1
+}
+
+// slot returns the index into the bundles of the given handle. It does not check the ownership.
+func slot(hnd int32) int {
+	return int(hnd & hndSlotMask)
+}
+
+// at returns the message data of the given handle, if the handle belongs to the parent of this bundle.
+func (b *Bundle) at(hnd int32) (strData, bool) {
+	if !b.parent.owns(hnd) {
+		return strData{}, false
+	}
+
+	return b.atSlot(slot(hnd))
+}
+
+// own is like [Bundle.at] but only returns the messages of this bundle itself, without those inherited by a region.
+func (b *Bundle) own(hnd int32) (strData, bool) {
+	if !b.parent.owns(hnd) {
+		return strData{}, false
+	}
+
+	return b.strings.At(slot(hnd))
+}
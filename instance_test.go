@@ -0,0 +1,113 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_IndependentInstances(t *testing.T) {
+	var a, b i18n.Resources
+	ha := option.Must(a.AddString("app.a", i18n.Values{language.English: "from a"}))
+	hb := option.Must(b.AddString("app.b", i18n.Values{language.English: "from b"}))
+	a.Flush()
+	b.Flush()
+
+	if ha.String() == hb.String() {
+		t.Fatal("handle strings of different instances must differ", ha.String())
+	}
+
+	enA := a.MustMatchBundle(language.English)
+	enB := b.MustMatchBundle(language.English)
+
+	if _, ok := enA.String(hb); ok {
+		t.Fatal("a foreign handle must not resolve to a local message")
+	}
+
+	if v := enA.Resolve(ha.String()); v != "from a" {
+		t.Fatal(v)
+	}
+
+	// foreign handle strings are resolved through their owner
+	if v := enA.Resolve(hb.String()); v != "from b" {
+		t.Fatal(v)
+	}
+
+	clone := b.Clone()
+	if v := hb.Get(clone.MustMatchBundle(language.English)); v != "from b" {
+		t.Fatal(v)
+	}
+
+	if v := enB.Resolve(hb.String()); v != "from b" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_InstanceSlotReuse(t *testing.T) {
+	stale := func() i18n.StrHnd {
+		var res i18n.Resources
+		option.Must(res.AddString("old.a", i18n.Values{language.English: "a"}))
+		return option.Must(res.AddString("old.b", i18n.Values{language.English: "b"}))
+	}()
+
+	runtime.GC()
+
+	// eventually a new instance reuses the slot of the collected one, but only allocates the first handle
+	var alive []*i18n.Resources
+	for {
+		res := &i18n.Resources{}
+		hnd := option.Must(res.AddString("new.a", i18n.Values{language.English: "a"}))
+		alive = append(alive, res)
+		if hnd == stale-1 {
+			break
+		}
+
+		if len(alive) > 2048 {
+			t.Fatal("slot of the collected instance has not been reused")
+		}
+	}
+
+	if v := stale.StableString(); v != stale.String() {
+		t.Fatal("the stable token of a collected instance must not survive", v)
+	}
+
+	runtime.KeepAlive(alive)
+}
+
+func TestResources_TooManyInstances(t *testing.T) {
+	var alive []*i18n.Resources
+	var err error
+	for range 2049 {
+		res := &i18n.Resources{}
+		alive = append(alive, res)
+		if _, err = res.AddString("app.a", i18n.Values{language.English: "a"}); err != nil {
+			break
+		}
+	}
+
+	if !errors.Is(err, i18n.ErrTooManyInstances) {
+		t.Fatal("expected too many instances", err)
+	}
+
+	// instances without keys are not registered, thus reading never fails
+	var res i18n.Resources
+	res.AddLanguage(language.English)
+	if _, ok := res.MatchString(language.English, 1); ok {
+		t.Fatal("expected no string")
+	}
+
+	// release the instance slots for the other tests
+	clear(alive)
+	runtime.GC()
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/worldiety/option"
+)
+
+// Namespace is a scoped registrar for independent modules or plugins. All keys are prefixed with the
+// namespace, thus declaring the same relative key in different namespaces does not collide.
+type Namespace struct {
+	parent *Resources
+	prefix string
+}
+
+// Namespace returns a scoped registrar whose keys are prefixed with the given prefix and a dot,
+// e.g. billing.invoice.title. Nested namespaces are created using [Namespace.Namespace].
+// The namespace is remembered, see [Resources.Namespaces]. An empty prefix denotes the root namespace, which
+// uses the keys unchanged and is not remembered.
+func (r *Resources) Namespace(prefix string) *Namespace {
+	prefix = strings.Trim(prefix, ".")
+	if prefix != "" {
+		r.namespaces.Put(prefix, struct{}{})
+	}
+
+	return &Namespace{parent: r, prefix: prefix}
+}
+
+// Namespaces returns the ascending sorted prefixes of all created namespaces.
+func (r *Resources) Namespaces() []string {
+	var tmp []string
+	for prefix := range r.namespaces.All() {
+		tmp = append(tmp, prefix)
+	}
+
+	slices.Sort(tmp)
+	return tmp
+}
+
+// Namespace returns a nested namespace.
+func (n *Namespace) Namespace(prefix string) *Namespace {
+	return n.parent.Namespace(n.join(strings.Trim(prefix, ".")))
+}
+
+// Prefix returns the prefix of this namespace without the trailing dot.
+func (n *Namespace) Prefix() string {
+	return n.prefix
+}
+
+// Parent returns the enclosing [Resources].
+func (n *Namespace) Parent() *Resources {
+	return n.parent
+}
+
+// Key returns the absolute key for the given relative key.
+func (n *Namespace) Key(key Key) Key {
+	return Key(n.join(strings.TrimPrefix(string(key), ".")))
+}
+
+// Contains returns true if the given absolute key belongs to this namespace or any nested namespace.
+// The root namespace contains all keys.
+func (n *Namespace) Contains(key Key) bool {
+	return n.prefix == "" || strings.HasPrefix(string(key), n.prefix+".")
+}
+
+func (n *Namespace) join(name string) string {
+	if n.prefix == "" {
+		return name
+	}
+
+	return n.prefix + "." + name
+}
+
+// Keys returns a snapshot of all ascending sorted absolute keys within this namespace.
+func (n *Namespace) Keys() []Key {
+	var tmp []Key
+	for _, key := range n.parent.SortedKeys() {
+		if n.Contains(key) {
+			tmp = append(tmp, key)
+		}
+	}
+
+	return tmp
+}
+
+// AddString declares the prefixed key. See [Resources.AddString].
+func (n *Namespace) AddString(key Key, values Values, opts ...Option) (StrHnd, error) {
+	return n.parent.AddString(n.Key(key), values, opts...)
+}
+
+// AddVarString declares the prefixed key. See [Resources.AddVarString].
+func (n *Namespace) AddVarString(key Key, values Values, opts ...Option) (VarStrHnd, error) {
+	return n.parent.AddVarString(n.Key(key), values, opts...)
+}
+
+// AddQuantityString declares the prefixed key. See [Resources.AddQuantityString].
+func (n *Namespace) AddQuantityString(key Key, values QValues, opts ...Option) (QStrHnd, error) {
+	return n.parent.AddQuantityString(n.Key(key), values, opts...)
+}
+
+// StringKey returns the handle of the prefixed key and declares it, if required. See [Resources.StringKey].
+func (n *Namespace) StringKey(key Key) StrHnd {
+	return n.parent.StringKey(n.Key(key))
+}
+
+// MustString declares the prefixed key and panics if it was already added. See [MustString].
+func (n *Namespace) MustString(key Key, values Values, opts ...Option) StrHnd {
+	return option.Must(n.AddString(key, values, opts...))
+}
+
+// MustVarString declares the prefixed key and panics if it was already added or if the template is
+// unparseable. See [MustVarString].
+func (n *Namespace) MustVarString(key Key, values Values, opts ...Option) VarStrHnd {
+	return option.Must(n.AddVarString(key, values, opts...))
+}
+
+// MustQuantityString declares the prefixed key and panics if it was already added or if the template is
+// unparseable. See [MustQuantityString].
+func (n *Namespace) MustQuantityString(key Key, values QValues, opts ...Option) QStrHnd {
+	return option.Must(n.AddQuantityString(key, values, opts...))
+}
+
+// ExportCSV writes only the keys of this namespace. See [Resources.ExportCSV].
+func (n *Namespace) ExportCSV(dst io.Writer, opts CSVOptions) error {
+	opts.Namespace = n.prefix
+	return n.parent.ExportCSV(dst, opts)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_Namespace(t *testing.T) {
+	var res i18n.Resources
+	billing := res.Namespace("billing")
+	shop := res.Namespace("shop")
+
+	a := billing.MustString("title", i18n.Values{language.English: "Invoices"}, i18n.LocalizationHint("page title"))
+	b := shop.MustString("title", i18n.Values{language.English: "Shop"})
+	option.Must(shop.Namespace("cart").AddString("empty", i18n.Values{language.English: "Cart is empty"}))
+	res.Flush()
+
+	if a == b || res.StringKey("billing.title") != a || shop.StringKey("title") != b {
+		t.Fatal("unexpected handles")
+	}
+
+	if v := b.Get(res.MustMatchBundle(language.English)); v != "Shop" {
+		t.Fatal(v)
+	}
+
+	if v := res.Namespaces(); !slices.Equal(v, []string{"billing", "shop", "shop.cart"}) {
+		t.Fatal(v)
+	}
+
+	if v := shop.Keys(); !slices.Equal(v, []i18n.Key{"shop.cart.empty", "shop.title"}) {
+		t.Fatal(v)
+	}
+
+	var buf bytes.Buffer
+	if err := billing.ExportCSV(&buf, i18n.CSVOptions{Comma: '\t'}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "key\tcategory\thint\tvar_hints\tdeprecation\taliases\ten\n" +
+		"billing.title\t\tpage title\t\t\t\tInvoices\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+}
+
+func TestResources_NamespaceRoot(t *testing.T) {
+	var res i18n.Resources
+	root := res.Namespace("")
+
+	hnd := root.MustString("title", i18n.Values{language.English: "Title"})
+	option.Must(root.Namespace("shop").AddString("title", i18n.Values{language.English: "Shop"}))
+
+	if root.Key("title") != "title" || res.StringKey("title") != hnd {
+		t.Fatal("root namespace must use the keys unchanged")
+	}
+
+	if v := res.Namespaces(); !slices.Equal(v, []string{"shop"}) {
+		t.Fatal(v)
+	}
+
+	if v := root.Keys(); !slices.Equal(v, []i18n.Key{"shop.title", "title"}) {
+		t.Fatal(v)
+	}
+}
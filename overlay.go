@@ -0,0 +1,134 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Derive returns a new empty overlay bundle, which shares the handle space and the tag with this bundle. Any
+// [Bundle.Update] on the overlay only affects the overlay itself (copy-on-write), thus lookups resolve the
+// overlay value first, then the value of this bundle and finally the fallback languages of the parent
+// [Resources]. The overlay is not registered within the parent, so use [Bundle.Flush] on the overlay
+// after mutation. Literal and message accessors like [Bundle.MessageByKey] only return the overridden values.
+// See also [Overlay] to manage the overlays of a tenant for all languages.
+func (b *Bundle) Derive() *Bundle {
+	return &Bundle{
+		parent: b.parent,
+		tag:    b.tag,
+		base:   b,
+	}
+}
+
+// Base returns the bundle from which this overlay was derived or nil if this is not an overlay.
+func (b *Bundle) Base() *Bundle {
+	return b.base
+}
+
+// Overlay holds the derived bundles of a single layer, e.g. the wording of a tenant or a user, for all
+// languages of its [Resources]. Overlays can be stacked by deriving from an existing Overlay.
+type Overlay struct {
+	parent   *Resources
+	base     *Overlay
+	children bufferedMap[language.Tag, *Bundle]
+	mutex    sync.Mutex
+}
+
+// NewOverlay creates a new empty overlay layer on top of this instance.
+func (r *Resources) NewOverlay() *Overlay {
+	return &Overlay{parent: r}
+}
+
+// Derive creates a new empty overlay layer on top of this overlay, e.g. for user overrides on top of
+// tenant overrides.
+func (o *Overlay) Derive() *Overlay {
+	return &Overlay{parent: o.parent, base: o}
+}
+
+// Bundle returns the overlay bundle for the exact tag and creates it, if the language is available in the
+// parent [Resources]. See also [Overlay.MatchBundle].
+func (o *Overlay) Bundle(tag language.Tag) (*Bundle, bool) {
+	// fast path
+	if b, ok := o.children.Get(tag); ok {
+		return b, true
+	}
+
+	var base *Bundle
+	if o.base != nil {
+		b, ok := o.base.Bundle(tag)
+		if !ok {
+			return nil, false
+		}
+
+		base = b
+	} else {
+		b, ok := o.parent.Bundle(tag)
+		if !ok {
+			return nil, false
+		}
+
+		base = b
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	// double check
+	if b, ok := o.children.Get(tag); ok {
+		return b, true
+	}
+
+	b := base.Derive()
+	o.children.Put(tag, b)
+	return b, true
+}
+
+// MatchBundle returns the overlay bundle of the best matching language. See [Resources.MatchBundle].
+func (o *Overlay) MatchBundle(tag language.Tag) (*Bundle, bool) {
+	b, ok := o.parent.MatchBundle(tag)
+	if !ok {
+		return nil, false
+	}
+
+	return o.Bundle(b.Tag())
+}
+
+// Flush optimizes all overlay bundles of this layer for read access, see [Resources.Flush].
+func (o *Overlay) Flush() {
+	o.children.Flush()
+	for _, b := range o.children.All() {
+		b.Flush()
+	}
+}
+
+// All returns all already created overlay bundles in stable language tag order.
+func (o *Overlay) All() iter.Seq2[language.Tag, *Bundle] {
+	var tags []language.Tag
+	for tag := range o.children.All() {
+		tags = append(tags, tag)
+	}
+
+	slices.SortFunc(tags, func(a, b language.Tag) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return func(yield func(language.Tag, *Bundle) bool) {
+		for _, tag := range tags {
+			if b, ok := o.children.Get(tag); ok {
+				if !yield(tag, b) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestOverlay(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	bye := option.Must(res.AddString("app.bye", i18n.Values{language.English: "bye"}))
+	res.Flush()
+
+	tenant := res.NewOverlay()
+	de, ok := tenant.MatchBundle(language.German)
+	if !ok {
+		t.Fatal("expected overlay bundle")
+	}
+
+	if err := de.Update(i18n.Message{Key: "app.hello", Value: "Moin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tenant.Flush()
+
+	if v := hello.Get(de); v != "Moin" {
+		t.Fatal(v)
+	}
+
+	// not overridden and falls through the base into the fallback language
+	if v := bye.Get(de); v != "bye" {
+		t.Fatal(v)
+	}
+
+	if v := de.Resolve(hello.String()); v != "Moin" {
+		t.Fatal(v)
+	}
+
+	if v := de.Resolve("app.bye"); v != "bye" {
+		t.Fatal(v)
+	}
+
+	// the base is untouched
+	if v := hello.Get(res.MustMatchBundle(language.German)); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	user := tenant.Derive()
+	userDE, ok := user.Bundle(language.German)
+	if !ok {
+		t.Fatal("expected overlay bundle")
+	}
+
+	if v := hello.Get(userDE); v != "Moin" {
+		t.Fatal(v)
+	}
+}
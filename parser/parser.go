@@ -135,3 +135,40 @@ func Parse(input string) ([]Token, error) {
 
 	return tokens, nil
 }
+
+// Format is the inverse of [Parse] and serializes the tokens into an input string. Literal apostrophes and
+// curly braces of text tokens are quoted according to the ICU MessageFormat apostrophe rules.
+//
+// Example:
+//
+//	Input tokens:
+//	  TEXT "Hello {notAVar} and "
+//	  VAR  "name"
+//	  TEXT ", 'quote' test"
+//	Output: "Hello '{'notAVar'}' and {name}, ''quote'' test"
+func Format(tokens []Token) string {
+	var buf strings.Builder
+	for _, token := range tokens {
+		if token.Type == VarToken {
+			buf.WriteByte('{')
+			buf.WriteString(token.Value)
+			buf.WriteByte('}')
+			continue
+		}
+
+		for i := 0; i < len(token.Value); i++ {
+			switch ch := token.Value[i]; ch {
+			case '\'':
+				buf.WriteString("''")
+			case '{', '}':
+				buf.WriteByte('\'')
+				buf.WriteByte(ch)
+				buf.WriteByte('\'')
+			default:
+				buf.WriteByte(ch)
+			}
+		}
+	}
+
+	return buf.String()
+}
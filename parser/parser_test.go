@@ -61,3 +61,24 @@ func TestParseUnclosedVariable(t *testing.T) {
 		t.Error("expected error for unclosed variable, got nil")
 	}
 }
+
+func TestFormatRoundTrip(t *testing.T) {
+	input := "Hello '{'notAVar'}' and {name}, ''quote'' test"
+	tokens, err := Parse(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := Format(tokens); got != input {
+		t.Errorf("Format(%+v) = %q, want %q", tokens, got, input)
+	}
+
+	got, err := Parse(Format(tokens))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, tokens) {
+		t.Errorf("Parse(Format()) = %+v, want %+v", got, tokens)
+	}
+}
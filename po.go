@@ -0,0 +1,475 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+type poEntry struct {
+	ctxt     string
+	id       string
+	idPlural string
+	str      []string
+	line     int
+}
+
+// parsePO reads the subset of the gettext PO format which is required to import translations. If an entry has
+// a msgctxt, it is used as the key and the msgid is treated as the source text. Otherwise, the msgid is the key.
+// Plural forms msgstr[n] are mapped onto the CLDR categories by evaluating the plural expression of the
+// Plural-Forms header. Without such a header, they are mapped in order onto the CLDR categories which are used by
+// the given language for integers, e.g. one, other for English or one, few, many for Polish.
+func parsePO(tag language.Tag, buf []byte) ([]catalogValue, error) {
+	var entries []poEntry
+	var cur *poEntry
+	var target *string
+
+	flush := func() {
+		if cur != nil {
+			entries = append(entries, *cur)
+		}
+
+		cur = nil
+		target = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, `"`) {
+			if target == nil {
+				return nil, fmt.Errorf("line %d: unexpected string continuation", lineNo)
+			}
+
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+
+			*target += s
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+		value, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		// a msgctxt or msgid after a msgstr starts a new entry, even without a blank line
+		if (keyword == "msgctxt" || keyword == "msgid") && cur != nil && len(cur.str) > 0 {
+			flush()
+		}
+
+		if cur == nil {
+			cur = &poEntry{line: lineNo}
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			cur.ctxt = value
+			target = &cur.ctxt
+		case keyword == "msgid":
+			cur.id = value
+			target = &cur.id
+		case keyword == "msgid_plural":
+			cur.idPlural = value
+			target = &cur.idPlural
+		case keyword == "msgstr":
+			cur.str = append(cur.str, value)
+			target = &cur.str[len(cur.str)-1]
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			idx, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || idx != len(cur.str) {
+				return nil, fmt.Errorf("line %d: invalid plural index %s", lineNo, keyword)
+			}
+
+			cur.str = append(cur.str, value)
+			target = &cur.str[len(cur.str)-1]
+		default:
+			return nil, fmt.Errorf("line %d: unsupported keyword %s", lineNo, keyword)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	var forms *poPluralForms
+	for _, e := range entries {
+		if e.ctxt == "" && e.id == "" && len(e.str) > 0 {
+			f, err := parsePOHeader(tag, e.str[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", e.line, err)
+			}
+
+			forms = f
+		}
+	}
+
+	categories := integerPluralCategories(tag)
+	var res []catalogValue
+	for _, e := range entries {
+		key := e.id
+		if e.ctxt != "" {
+			key = e.ctxt
+		}
+
+		// the header entry and untranslated entries are ignored
+		if key == "" || len(e.str) == 0 {
+			continue
+		}
+
+		if e.idPlural == "" {
+			if e.str[0] == "" {
+				continue
+			}
+
+			res = append(res, catalogValue{key: Key(key), value: e.str[0]})
+			continue
+		}
+
+		var quantities Quantities
+		if forms != nil {
+			if len(e.str) > forms.count {
+				return nil, fmt.Errorf("line %d: %v has %d plural forms but the header declares %d", e.line, key, len(e.str), forms.count)
+			}
+
+			for category, idx := range forms.indices {
+				if idx < len(e.str) {
+					quantities.setCategory(category, e.str[idx])
+				}
+			}
+		} else {
+			if len(e.str) > len(categories) {
+				return nil, fmt.Errorf("line %d: %v has %d plural forms but %v only uses %d", e.line, key, len(e.str), tag, len(categories))
+			}
+
+			for i, str := range e.str {
+				quantities.setCategory(categories[i], str)
+			}
+		}
+
+		if quantities.IsZero() {
+			continue
+		}
+
+		res = append(res, catalogValue{key: Key(key), quantities: quantities, plural: true})
+	}
+
+	return res, nil
+}
+
+var pluralForms = map[string]plural.Form{
+	"zero": plural.Zero, "one": plural.One, "two": plural.Two, "few": plural.Few, "many": plural.Many, "other": plural.Other,
+}
+
+// integerPluralCategories returns the CLDR plural category names in canonical order, which the given language
+// selects for the integers up to 1000. This is the order which gettext uses for the msgstr[n] forms.
+func integerPluralCategories(tag language.Tag) []string {
+	var used [plural.Many + 1]bool
+	for i := 0; i <= 1000; i++ {
+		used[plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0)] = true
+	}
+
+	var res []string
+	for _, name := range quantityCategories {
+		if used[pluralForms[name]] {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// poPluralForms maps the CLDR categories onto the msgstr[n] indices of the Plural-Forms header.
+type poPluralForms struct {
+	count   int
+	indices map[string]int
+}
+
+// parsePOHeader evaluates the Plural-Forms header like "nplurals=3; plural=(n==1 ? 0 : n%10>=2 ? 1 : 2);" and
+// returns nil, if the header does not declare plural forms. Each CLDR category, which the language selects for
+// integers, is mapped onto the index which the plural expression returns for most integers of that category,
+// because the gettext rules of some languages slightly differ from CLDR.
+func parsePOHeader(tag language.Tag, header string) (*poPluralForms, error) {
+	var value string
+	for line := range strings.SplitSeq(header, "\n") {
+		if name, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Plural-Forms") {
+			value = v
+		}
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	forms := &poPluralForms{indices: map[string]int{}}
+	var expr string
+	for part := range strings.SplitSeq(value, ";") {
+		name, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.TrimSpace(name) {
+		case "nplurals":
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid nplurals in Plural-Forms header: %s", v)
+			}
+
+			forms.count = n
+		case "plural":
+			expr = v
+		}
+	}
+
+	if forms.count == 0 || expr == "" {
+		return nil, fmt.Errorf("invalid Plural-Forms header: %s", value)
+	}
+
+	p := pluralParser{src: expr}
+	eval := p.ternary()
+	if p.skipSpace(); p.err == nil && p.pos < len(p.src) {
+		p.fail()
+	}
+
+	if p.err != nil {
+		return nil, fmt.Errorf("invalid plural expression %q: %w", expr, p.err)
+	}
+
+	var votes [plural.Many + 1]map[int]int
+	for n := 0; n <= 1000; n++ {
+		idx := eval(n)
+		if idx < 0 || idx >= forms.count {
+			return nil, fmt.Errorf("plural expression %q returns %d for %d but nplurals is %d", expr, idx, n, forms.count)
+		}
+
+		form := plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)
+		if votes[form] == nil {
+			votes[form] = map[int]int{}
+		}
+
+		votes[form][idx]++
+	}
+
+	for _, name := range quantityCategories {
+		counts := votes[pluralForms[name]]
+		if counts == nil {
+			continue
+		}
+
+		best := -1
+		for idx := range forms.count {
+			if best < 0 || counts[idx] > counts[best] {
+				best = idx
+			}
+		}
+
+		forms.indices[name] = best
+	}
+
+	return forms, nil
+}
+
+// pluralParser is a recursive descent parser for the C subset of gettext plural expressions. Each rule returns
+// a closure which evaluates the expression for a given n.
+type pluralParser struct {
+	src string
+	pos int
+	err error
+}
+
+func (p *pluralParser) fail() {
+	if p.err == nil {
+		p.err = fmt.Errorf("unexpected input at %d", p.pos)
+	}
+
+	p.pos = len(p.src)
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// accept consumes the given operator, but not a prefix of a longer operator like < of <=.
+func (p *pluralParser) accept(op string) bool {
+	p.skipSpace()
+	if !strings.HasPrefix(p.src[p.pos:], op) {
+		return false
+	}
+
+	if len(op) == 1 && p.pos+1 < len(p.src) && strings.ContainsRune("<>=!", rune(op[0])) && p.src[p.pos+1] == '=' {
+		return false
+	}
+
+	p.pos += len(op)
+	return true
+}
+
+func (p *pluralParser) ternary() func(int) int {
+	cond := p.binary(0)
+	if !p.accept("?") {
+		return cond
+	}
+
+	yes := p.ternary()
+	if !p.accept(":") {
+		p.fail()
+	}
+
+	no := p.ternary()
+	return func(n int) int {
+		if cond(n) != 0 {
+			return yes(n)
+		}
+
+		return no(n)
+	}
+}
+
+// pluralOperators lists the binary operators by ascending precedence.
+var pluralOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pluralParser) binary(level int) func(int) int {
+	if level == len(pluralOperators) {
+		return p.unary()
+	}
+
+	lhs := p.binary(level + 1)
+	for {
+		op := ""
+		for _, candidate := range pluralOperators[level] {
+			if p.accept(candidate) {
+				op = candidate
+				break
+			}
+		}
+
+		if op == "" {
+			return lhs
+		}
+
+		lhs = pluralOperator(op, lhs, p.binary(level+1))
+	}
+}
+
+func pluralOperator(op string, a, b func(int) int) func(int) int {
+	bool2int := func(v bool) int {
+		if v {
+			return 1
+		}
+
+		return 0
+	}
+
+	switch op {
+	case "||":
+		return func(n int) int { return bool2int(a(n) != 0 || b(n) != 0) }
+	case "&&":
+		return func(n int) int { return bool2int(a(n) != 0 && b(n) != 0) }
+	case "==":
+		return func(n int) int { return bool2int(a(n) == b(n)) }
+	case "!=":
+		return func(n int) int { return bool2int(a(n) != b(n)) }
+	case "<=":
+		return func(n int) int { return bool2int(a(n) <= b(n)) }
+	case ">=":
+		return func(n int) int { return bool2int(a(n) >= b(n)) }
+	case "<":
+		return func(n int) int { return bool2int(a(n) < b(n)) }
+	case ">":
+		return func(n int) int { return bool2int(a(n) > b(n)) }
+	case "+":
+		return func(n int) int { return a(n) + b(n) }
+	case "-":
+		return func(n int) int { return a(n) - b(n) }
+	case "*":
+		return func(n int) int { return a(n) * b(n) }
+	default:
+		// division by zero is undefined in C, just avoid the panic
+		return func(n int) int {
+			d := b(n)
+			if d == 0 {
+				return 0
+			}
+
+			if op == "/" {
+				return a(n) / d
+			}
+
+			return a(n) % d
+		}
+	}
+}
+
+func (p *pluralParser) unary() func(int) int {
+	if p.accept("!") {
+		v := p.unary()
+		return func(n int) int {
+			if v(n) == 0 {
+				return 1
+			}
+
+			return 0
+		}
+	}
+
+	if p.accept("(") {
+		v := p.ternary()
+		if !p.accept(")") {
+			p.fail()
+		}
+
+		return v
+	}
+
+	p.skipSpace()
+	if p.accept("n") {
+		return func(n int) int { return n }
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+
+	v, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		p.fail()
+		return func(int) int { return 0 }
+	}
+
+	return func(int) int { return v }
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/worldiety/i18n/parser"
+	"golang.org/x/text/language"
+)
+
+// PseudoLocaleOptions configure the generation of a pseudo-localized bundle, see [Resources.AddPseudoLocale].
+type PseudoLocaleOptions struct {
+	// Source is the language to pseudo-localize. Defaults to the first tag of [Resources.SetPriorities], which is
+	// the last resort fallback and usually the language the messages are written in. Without priorities, the first
+	// of [Resources.Tags] is used, just like [Resources.MatchTag] repairs missing priorities.
+	Source language.Tag
+
+	// Accents replaces ASCII letters by accented look-alikes, e.g. "Settings" becomes "Šéţţîñĝš", so that
+	// hard-coded strings stand out and the text stays readable.
+	Accents bool
+
+	// Padding extends each message by the given percentage of its text length, to simulate the longer texts of
+	// other languages. A typical value is 30 or 40.
+	Padding int
+
+	// Brackets encloses each message in [ and ], so that truncated or concatenated messages become visible.
+	Brackets bool
+
+	// Mirror forces a right-to-left rendering of the text while keeping the characters readable, to find the
+	// layout issues of RTL languages.
+	Mirror bool
+}
+
+// PseudoAccented returns the options of the common en-XA pseudo locale.
+func PseudoAccented() PseudoLocaleOptions {
+	return PseudoLocaleOptions{Accents: true, Padding: 30, Brackets: true}
+}
+
+// PseudoBidi returns the options of the common ar-XB pseudo locale.
+func PseudoBidi() PseudoLocaleOptions {
+	return PseudoLocaleOptions{Mirror: true}
+}
+
+// AddPseudoLocale adds a bundle like en-XA or ar-XB, which contains a pseudo-localized copy of each message
+// of the source language. Template variables and the plural categories are preserved. Categories which are
+// undefined in the source but required by the plural rules of the given tag, like the dual form of ar-XB, use
+// the pseudo-localized other form. The messages are a snapshot, thus later changes of the source are not
+// reflected. The bundle is registered only after all messages have been generated, thus it never becomes visible
+// partially. Returns [os.ErrExist] if the language has already been added.
+func (r *Resources) AddPseudoLocale(tag language.Tag, opts PseudoLocaleOptions) (*Bundle, error) {
+	if opts.Source == language.Und {
+		for t := range r.Priorities() {
+			opts.Source = t
+			break
+		}
+	}
+
+	if opts.Source == language.Und {
+		if tags := r.Tags(); len(tags) > 0 {
+			opts.Source = tags[0]
+		}
+	}
+
+	src, ok := r.Bundle(opts.Source)
+	if !ok {
+		return nil, fmt.Errorf("cannot add pseudo locale %v: no such source language: %v", tag, opts.Source)
+	}
+
+	if _, ok := r.Bundle(tag); ok {
+		return nil, fmt.Errorf("cannot add pseudo locale %v: %w", tag, os.ErrExist)
+	}
+
+	bnd := newBundle(r, tag)
+	var events []Event
+	for _, key := range r.SortedKeys() {
+		msg := src.MessageByKey(key)
+		if !msg.Valid() {
+			continue
+		}
+
+		pseudo, err := opts.message(msg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot pseudo-localize %v: %w", key, err)
+		}
+
+		hnd, pseudo, data, err := bnd.prepare(pseudo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot pseudo-localize %v: %w", key, err)
+		}
+
+		bnd.strings.Set(slot(hnd), data)
+		events = append(events, Event{Kind: EventMessageUpdated, Key: pseudo.Key, Tag: tag, New: pseudo})
+	}
+
+	bnd.Flush()
+
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.children.Get(tag); ok {
+		return nil, fmt.Errorf("cannot add pseudo locale %v: %w", tag, os.ErrExist)
+	}
+
+	r.priorities.Append(tag)
+	r.putBundle(bnd)
+	for _, evt := range events {
+		r.emit(evt)
+	}
+
+	r.flush()
+
+	return bnd, nil
+}
+
+func (o PseudoLocaleOptions) message(msg Message) (Message, error) {
+	var err error
+	switch msg.Kind {
+	case MessageString:
+		msg.Value = o.apply([]parser.Token{{Type: parser.TextToken, Value: msg.Value}}, false)
+	case MessageVarString:
+		msg.Value, err = o.template(msg.Value)
+	case MessageQuantities:
+		q := &msg.Quantities
+		for _, v := range []*string{&q.Zero, &q.One, &q.Two, &q.Few, &q.Many, &q.Other} {
+			if *v == "" {
+				continue
+			}
+
+			if *v, err = o.template(*v); err != nil {
+				return msg, err
+			}
+		}
+
+		for _, v := range []*string{&q.Zero, &q.One, &q.Two, &q.Few, &q.Many} {
+			if *v == "" {
+				*v = q.Other
+			}
+		}
+	}
+
+	return msg, err
+}
+
+func (o PseudoLocaleOptions) template(text string) (string, error) {
+	tokens, err := parser.Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	return o.apply(tokens, true), nil
+}
+
+// apply transforms the text tokens and serializes the result. Variables are kept as is.
+func (o PseudoLocaleOptions) apply(tokens []parser.Token, template bool) string {
+	length := 0
+	for i, token := range tokens {
+		if token.Type != parser.TextToken {
+			continue
+		}
+
+		length += len([]rune(token.Value))
+		if o.Accents {
+			tokens[i].Value = strings.Map(accent, token.Value)
+		}
+	}
+
+	if pad := pseudoPadding((length*o.Padding + 99) / 100); pad != "" {
+		tokens = append(tokens, parser.Token{Type: parser.TextToken, Value: pad})
+	}
+
+	if o.Mirror {
+		for i, token := range tokens {
+			if token.Type == parser.TextToken {
+				// right-to-left mark and override, terminated by the pop directional formatting
+				tokens[i].Value = "\u200f\u202e" + token.Value + "\u202c\u200f"
+			}
+		}
+	}
+
+	if o.Brackets {
+		tokens = append([]parser.Token{{Type: parser.TextToken, Value: "["}}, tokens...)
+		tokens = append(tokens, parser.Token{Type: parser.TextToken, Value: "]"})
+	}
+
+	if template {
+		return parser.Format(tokens)
+	}
+
+	var buf strings.Builder
+	for _, token := range tokens {
+		buf.WriteString(token.Value)
+	}
+
+	return buf.String()
+}
+
+const (
+	pseudoPlain    = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	pseudoAccented = "åƀçđéƒĝĥîĵķļɱñöþǫŕšţûṽŵẋýžÅƁÇĐÉƑĜĤÎĴĶĻṀÑÖÞǪŔŠŢÛṼŴẊÝŽ"
+	pseudoFiller   = " one two three four five six seven eight nine ten"
+)
+
+var pseudoAccents = func() map[rune]rune {
+	accented := []rune(pseudoAccented)
+	m := make(map[rune]rune, len(accented))
+	for i, r := range pseudoPlain {
+		m[r] = accented[i]
+	}
+
+	return m
+}()
+
+func accent(r rune) rune {
+	if a, ok := pseudoAccents[r]; ok {
+		return a
+	}
+
+	return r
+}
+
+// pseudoPadding returns n runes of readable filler words, which still allow line breaks.
+func pseudoPadding(n int) string {
+	var buf strings.Builder
+	for i := 0; i < n; i++ {
+		buf.WriteByte(pseudoFiller[i%len(pseudoFiller)])
+	}
+
+	return buf.String()
+}
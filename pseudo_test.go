@@ -0,0 +1,116 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_AddPseudoLocale(t *testing.T) {
+	var res i18n.Resources
+	res.AddLanguage(language.English)
+	settings := option.Must(res.AddString("app.settings", i18n.Values{language.English: "Settings"}))
+	greet := option.Must(res.AddVarString("app.greet", i18n.Values{language.English: "Hi '{'{name}'}'"}))
+	apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{
+		language.English: {One: "one apple", Other: "{n} apples"},
+	}))
+	res.Flush()
+
+	enXA := language.MustParse("en-XA")
+	xa, err := res.AddPseudoLocale(enXA, i18n.PseudoAccented())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := settings.Get(xa); v != "[Šéţţîñĝš on]" {
+		t.Fatal(v)
+	}
+
+	if v := greet.Get(xa, i18n.String("name", "Bob")); v != "[Ĥî {Bob} o]" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(xa, 1); v != "[öñé åþþļé on]" {
+		t.Fatal(v)
+	}
+
+	if v := apples.Get(xa, 3, i18n.Int("n", 3)); v != "[3 åþþļéš on]" {
+		t.Fatal(v)
+	}
+
+	if _, err := res.AddPseudoLocale(enXA, i18n.PseudoAccented()); !errors.Is(err, os.ErrExist) {
+		t.Fatal(err)
+	}
+
+	xb, err := res.AddPseudoLocale(language.MustParse("ar-XB"), i18n.PseudoBidi())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the arabic dual form is not defined by the english source
+	if v := apples.Get(xb, 2, i18n.Int("n", 2)); v != "2\u200f\u202e apples\u202c\u200f" {
+		t.Fatalf("%q", v)
+	}
+
+	if v := settings.Get(xb); !strings.Contains(v, "Settings") || !strings.HasPrefix(v, "\u200f\u202e") {
+		t.Fatalf("%q", v)
+	}
+}
+
+func TestResources_AddPseudoLocaleComplete(t *testing.T) {
+	var res i18n.Resources
+	res.SetPriorities(language.English)
+	for i := range 1000 {
+		option.Must(res.AddString(i18n.Key("app."+strconv.Itoa(i)), i18n.Values{language.English: "text"}))
+	}
+
+	res.Flush()
+	xa := language.MustParse("en-XA")
+
+	// a concurrent reader must never see the pseudo locale without all of its messages
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if bnd, ok := res.Bundle(xa); ok {
+				if msg := bnd.MessageByKey("app.999"); !msg.Valid() {
+					t.Error("pseudo locale is visible before it is complete")
+				}
+
+				return
+			}
+		}
+	}()
+
+	option.Must(res.AddPseudoLocale(xa, i18n.PseudoAccented()))
+	<-done
+}
+
+func TestResources_AddPseudoLocaleDefaultSource(t *testing.T) {
+	// the languages are only known from the values, thus there are no priorities
+	var res i18n.Resources
+	settings := option.Must(res.AddString("settings", i18n.Values{language.English: "Settings"}))
+	res.Flush()
+
+	xa, err := res.AddPseudoLocale(language.MustParse("en-XA"), i18n.PseudoAccented())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := settings.Get(xa); v != "[Šéţţîñĝš on]" {
+		t.Fatal(v)
+	}
+}
@@ -9,6 +9,7 @@ package i18n
 
 import (
 	"fmt"
+	"iter"
 	"math"
 	"strings"
 
@@ -171,3 +172,61 @@ func decomposeNumber(n float64) (i, v, f, t int) {
 
 	return i, v, f, t
 }
+
+// quantityCategories lists the CLDR plural category names in their canonical order.
+var quantityCategories = [...]string{"zero", "one", "two", "few", "many", "other"}
+
+// All returns the CLDR category names and their messages in canonical order, omitting empty categories.
+func (q Quantities) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, name := range quantityCategories {
+			if v, _ := q.category(name); v != "" {
+				if !yield(name, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// category returns the message for the given CLDR category name.
+func (q Quantities) category(name string) (string, bool) {
+	switch name {
+	case "zero":
+		return q.Zero, true
+	case "one":
+		return q.One, true
+	case "two":
+		return q.Two, true
+	case "few":
+		return q.Few, true
+	case "many":
+		return q.Many, true
+	case "other":
+		return q.Other, true
+	default:
+		return "", false
+	}
+}
+
+// setCategory updates the message for the given CLDR category name and returns false if the name is unknown.
+func (q *Quantities) setCategory(name string, value string) bool {
+	switch name {
+	case "zero":
+		q.Zero = value
+	case "one":
+		q.One = value
+	case "two":
+		q.Two = value
+	case "few":
+		q.Few = value
+	case "many":
+		q.Many = value
+	case "other":
+		q.Other = value
+	default:
+		return false
+	}
+
+	return true
+}
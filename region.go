@@ -0,0 +1,160 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// inheritedTable is the precomputed view of a regional bundle, which refers to the own messages merged over
+// the messages of the base bundle. The references point into the immutable flushed slices, so that no message is
+// copied. It is only valid as long as both source buffers have not been changed.
+type inheritedTable struct {
+	own  *[]strData
+	base any // the view of the base bundle, see [Bundle.view]
+	data []*strData
+}
+
+// noStrData is the view of a flushed but empty bundle.
+var noStrData []strData
+
+// AddRegion adds a regional variant bundle like de-AT or en-GB, which only stores the differing messages
+// and inherits everything else from the already existing base language bundle, e.g. "Jänner" instead of "Januar".
+// The inherited messages are precomputed per handle by [Resources.Flush], thus lookups stay O(1).
+// Literal and message accessors like [Bundle.MessageByKey] only return the own messages of the region.
+// Returns [os.ErrExist] if the language has already been added.
+func (r *Resources) AddRegion(tag language.Tag, base language.Tag) (*Bundle, error) {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.children.Get(tag); ok {
+		return nil, fmt.Errorf("cannot add region %v: %w", tag, os.ErrExist)
+	}
+
+	baseBnd, ok := r.children.Get(base)
+	if !ok {
+		return nil, fmt.Errorf("cannot add region %v: no such base language: %v", tag, base)
+	}
+
+	bnd := newBundle(r, tag)
+	bnd.inherits.Store(baseBnd)
+	r.putBundle(bnd)
+
+	return bnd, nil
+}
+
+// Inherits returns the base language bundle of a regional bundle or nil. See [Resources.AddRegion].
+func (b *Bundle) Inherits() *Bundle {
+	return b.inherits.Load()
+}
+
+// atSlot returns the message including the inherited ones.
+func (b *Bundle) atSlot(s int) (strData, bool) {
+	base := b.inherits.Load()
+	if base == nil {
+		return b.strings.At(s)
+	}
+
+	// fast path
+	if t := b.inherited.Load(); t != nil && b.fresh(t, base) {
+		if s >= len(t.data) {
+			return strData{}, false
+		}
+
+		if data := t.data[s]; data != nil {
+			return *data, true
+		}
+
+		return strData{}, true
+	}
+
+	// slow path, not flushed yet
+	if data, ok := b.strings.At(s); ok && data.kind != MessageUndefined {
+		return data, true
+	}
+
+	return base.atSlot(s)
+}
+
+// view returns the flushed messages including the inherited ones, which is either the flushed slice of a plain
+// bundle or the inherited table of a region. It returns nil, if not flushed.
+func (b *Bundle) view() any {
+	base := b.inherits.Load()
+	if base == nil {
+		if b.strings.isDirty() {
+			return nil
+		}
+
+		if v := b.strings.readSlice.Load(); v != nil {
+			return v
+		}
+
+		return &noStrData
+	}
+
+	if t := b.inherited.Load(); t != nil && b.fresh(t, base) {
+		return t
+	}
+
+	return nil
+}
+
+// refs returns new references to the messages of the given view, see [Bundle.view].
+func refs(view any) []*strData {
+	switch v := view.(type) {
+	case *[]strData:
+		tmp := make([]*strData, len(*v))
+		for i := range *v {
+			tmp[i] = &(*v)[i]
+		}
+
+		return tmp
+	case *inheritedTable:
+		return slices.Clone(v.data)
+	default:
+		return nil
+	}
+}
+
+func (b *Bundle) fresh(t *inheritedTable, base *Bundle) bool {
+	return !b.strings.isDirty() && t.own == b.strings.readSlice.Load() && t.base != nil && t.base == base.view()
+}
+
+// flushInherited recomputes the inherited table of a regional bundle, if required.
+func (b *Bundle) flushInherited() {
+	base := b.inherits.Load()
+	if base == nil {
+		return
+	}
+
+	base.Flush()
+	baseView := base.view()
+	own := b.strings.readSlice.Load()
+	if t := b.inherited.Load(); t != nil && t.own == own && t.base == baseView {
+		return
+	}
+
+	merged := refs(baseView)
+	if own != nil {
+		for i := range *own {
+			if i >= len(merged) {
+				merged = append(merged, nil)
+			}
+
+			if (*own)[i].kind != MessageUndefined {
+				merged[i] = &(*own)[i]
+			}
+		}
+	}
+
+	b.inherited.Store(&inheritedTable{own: own, base: baseView, data: merged})
+}
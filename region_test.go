@@ -0,0 +1,133 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_AddRegion(t *testing.T) {
+	deAT := language.MustParse("de-AT")
+
+	var res i18n.Resources
+	january := option.Must(res.AddString("month.january", i18n.Values{language.German: "Januar"}))
+	february := option.Must(res.AddString("month.february", i18n.Values{language.German: "Februar"}))
+
+	at := option.Must(res.AddRegion(deAT, language.German))
+	if _, err := res.AddRegion(deAT, language.German); !errors.Is(err, os.ErrExist) {
+		t.Fatal(err)
+	}
+
+	if err := at.Update(i18n.Message{Key: "month.january", Value: "Jänner"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// not flushed yet
+	if v := february.Get(at); v != "Februar" {
+		t.Fatal(v)
+	}
+
+	res.Flush()
+
+	if at.Inherits() != res.MustMatchBundle(language.German) {
+		t.Fatal("expected base bundle")
+	}
+
+	if v := january.Get(res.MustMatchBundle(deAT)); v != "Jänner" {
+		t.Fatal(v)
+	}
+
+	if v := february.Get(at); v != "Februar" {
+		t.Fatal(v)
+	}
+
+	if v := at.MessageByKey("month.february"); v.Valid() {
+		t.Fatal("regions only contain the differing messages", v)
+	}
+
+	if v, ok := at.StringLiteral(february); ok {
+		t.Fatal("literals must not fall through to the base", v)
+	}
+
+	if v, ok := at.StringLiteral(january); !ok || v != "Jänner" {
+		t.Fatal(v)
+	}
+
+	// changes of the base are visible before and after the next flush
+	de := res.MustMatchBundle(language.German)
+	if err := de.Update(i18n.Message{Key: "month.february", Value: "Feber?"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := february.Get(at); v != "Feber?" {
+		t.Fatal(v)
+	}
+
+	res.Flush()
+	if v := february.Get(at); v != "Feber?" {
+		t.Fatal(v)
+	}
+
+	restored := &i18n.Resources{}
+	if err := restored.UnmarshalBinary(option.Must(res.MarshalBinary())); err != nil {
+		t.Fatal(err)
+	}
+
+	restoredAT, ok := restored.Bundle(deAT)
+	if !ok || restoredAT.Inherits() == nil {
+		t.Fatal("expected restored region")
+	}
+
+	if v := restoredAT.Resolve("month.february"); v != "Feber?" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_AddRegionNested(t *testing.T) {
+	var res i18n.Resources
+	january := option.Must(res.AddString("month.january", i18n.Values{language.German: "Januar"}))
+	february := option.Must(res.AddString("month.february", i18n.Values{language.German: "Februar"}))
+	march := option.Must(res.AddString("month.march", i18n.Values{language.German: "März"}))
+
+	at := option.Must(res.AddRegion(language.MustParse("de-AT"), language.German))
+	vienna := option.Must(res.AddRegion(language.MustParse("de-AT-1996"), language.MustParse("de-AT")))
+	if err := at.Update(i18n.Message{Key: "month.january", Value: "Jänner"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := vienna.Update(i18n.Message{Key: "month.february", Value: "Feber"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res.Flush()
+
+	if v := january.Get(vienna) + " " + february.Get(vienna) + " " + march.Get(vienna); v != "Jänner Feber März" {
+		t.Fatal(v)
+	}
+
+	// the inherited references must follow a changed base after the next flush
+	if err := res.MustMatchBundle(language.German).Update(i18n.Message{Key: "month.march", Value: "Merz"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res.Flush()
+
+	if v := march.Get(vienna); v != "Merz" {
+		t.Fatal(v)
+	}
+
+	if v := february.Get(at); v != "Februar" {
+		t.Fatal(v)
+	}
+}
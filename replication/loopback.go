@@ -0,0 +1,87 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package replication
+
+import (
+	"slices"
+	"sync"
+)
+
+// Loopback connects the replicas of a single process, which is useful for tests. Change sets are delivered
+// synchronously to all other endpoints.
+type Loopback struct {
+	mutex     sync.Mutex
+	endpoints []*endpoint
+}
+
+// NewLoopback creates an empty loopback network.
+func NewLoopback() *Loopback {
+	return &Loopback{}
+}
+
+// Transport creates a new endpoint within the loopback network.
+func (l *Loopback) Transport() Transport {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	ep := &endpoint{network: l}
+	l.endpoints = append(l.endpoints, ep)
+	return ep
+}
+
+type endpoint struct {
+	network   *Loopback
+	mutex     sync.Mutex
+	lastID    int
+	receivers []receiver
+}
+
+type receiver struct {
+	id int
+	fn func(ChangeSet)
+}
+
+func (e *endpoint) Publish(cs ChangeSet) error {
+	e.network.mutex.Lock()
+	endpoints := slices.Clone(e.network.endpoints)
+	e.network.mutex.Unlock()
+
+	for _, other := range endpoints {
+		if other == e {
+			continue
+		}
+
+		other.mutex.Lock()
+		receivers := slices.Clone(other.receivers)
+		other.mutex.Unlock()
+
+		for _, rcv := range receivers {
+			rcv.fn(cs)
+		}
+	}
+
+	return nil
+}
+
+func (e *endpoint) Receive(fn func(ChangeSet)) (stop func()) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.lastID++
+	id := e.lastID
+	e.receivers = append(e.receivers, receiver{id: id, fn: fn})
+
+	return func() {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+
+		e.receivers = slices.DeleteFunc(e.receivers, func(rcv receiver) bool {
+			return rcv.id == id
+		})
+	}
+}
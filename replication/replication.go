@@ -0,0 +1,269 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+// Package replication keeps the runtime changes of multiple [i18n.Resources] replicas in sync, e.g. translations
+// which have been fixed by [i18n.Bundle.Update] on one node of a cluster. Changes are exchanged as change sets
+// over a pluggable [Transport] and applied idempotently with last-writer-wins semantics.
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/worldiety/i18n"
+	"golang.org/x/text/language"
+)
+
+// MetadataOrigin is the [i18n.UpdateMetadata] key which marks an update as applied from a remote node. Such
+// updates are not published again. The value is the node which has made the change.
+const MetadataOrigin = "replication.origin"
+
+// Version orders the changes of a message across all nodes. It is a Lamport timestamp, thus the clock is
+// advanced beyond every received version and equal clocks are ordered by the node name.
+type Version struct {
+	Clock uint64 `json:"clock"`
+	Node  string `json:"node"`
+}
+
+// After reports whether v supersedes the other version.
+func (v Version) After(other Version) bool {
+	if v.Clock != other.Clock {
+		return v.Clock > other.Clock
+	}
+
+	return v.Node > other.Node
+}
+
+// Change describes a single changed message of a language.
+type Change struct {
+	Tag     language.Tag `json:"tag"`
+	Key     i18n.Key     `json:"key"`
+	Message i18n.Message `json:"message,omitzero"` // not valid, if the message was deleted
+	Author  string       `json:"author,omitempty"`
+	Version Version      `json:"version"`
+}
+
+// ChangeSet is a batch of changes sent by a single node.
+type ChangeSet struct {
+	Node    string   `json:"node"`
+	Changes []Change `json:"changes"`
+}
+
+// A Transport delivers change sets between the nodes. Implementations must be safe for concurrent use and
+// should not deliver a change set back to its sender, although doing so is harmless.
+type Transport interface {
+	// Publish sends the change set to all other nodes.
+	Publish(cs ChangeSet) error
+
+	// Receive registers the function which is called for each change set of other nodes. Call the returned
+	// function to stop receiving.
+	Receive(fn func(ChangeSet)) (stop func())
+}
+
+// Options configure a [Replicator].
+type Options struct {
+	// Node is the unique name of this replica within the cluster, e.g. a hostname. Required.
+	Node string
+
+	// OnError is called for changes which cannot be published or applied, e.g. due to an unknown key. If nil,
+	// errors are dropped.
+	OnError func(error)
+}
+
+type entry struct {
+	tag language.Tag
+	key i18n.Key
+}
+
+// Replicator publishes the local message changes of a [i18n.Resources] instance and applies the changes of
+// other nodes. Messages which have been declared or loaded before the replicator was created are not published,
+// so each node must start with the same declarations, e.g. from the same catalogs.
+type Replicator struct {
+	res       *i18n.Resources
+	transport Transport
+	opts      Options
+	mutex     sync.Mutex
+	clock     uint64
+	latest    map[entry]Change
+	locks     map[entry]*sync.Mutex
+	stop      []func()
+}
+
+// New creates a replicator and starts listening for local and remote changes. Call [Replicator.Close] to stop.
+func New(res *i18n.Resources, transport Transport, opts Options) (*Replicator, error) {
+	if opts.Node == "" {
+		return nil, fmt.Errorf("replication node name is required")
+	}
+
+	r := &Replicator{
+		res:       res,
+		transport: transport,
+		opts:      opts,
+		latest:    map[entry]Change{},
+		locks:     map[entry]*sync.Mutex{},
+	}
+
+	r.stop = append(r.stop, res.Subscribe(r.onEvent), transport.Receive(func(cs ChangeSet) {
+		if err := r.Apply(cs); err != nil {
+			r.fail(err)
+		}
+	}))
+
+	return r, nil
+}
+
+// Close stops publishing and receiving changes.
+func (r *Replicator) Close() {
+	r.mutex.Lock()
+	stop := r.stop
+	r.stop = nil
+	r.mutex.Unlock()
+
+	for _, fn := range stop {
+		fn()
+	}
+}
+
+// Changes returns the latest known change of each message, which can be published to bring a new node up to
+// date.
+func (r *Replicator) Changes() ChangeSet {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cs := ChangeSet{Node: r.opts.Node}
+	for _, c := range r.latest {
+		cs.Changes = append(cs.Changes, c)
+	}
+
+	return cs
+}
+
+// Apply updates the local messages with all changes of the set, which are newer than the known version of
+// the same message. Applying the same change set multiple times has no further effect. Failed changes are
+// skipped and returned as a joined error. They are not recorded, so that applying them again succeeds as soon
+// as the cause has been fixed, e.g. the key has been declared.
+func (r *Replicator) Apply(cs ChangeSet) error {
+	var errs []error
+	for _, c := range cs.Changes {
+		unlock := r.lock(entry{tag: c.Tag, key: c.Key})
+		if r.newer(c) {
+			if err := r.apply(c); err != nil {
+				errs = append(errs, fmt.Errorf("cannot apply change of %v [%v] from %s: %w", c.Key, c.Tag, c.Version.Node, err))
+			} else {
+				r.record(c)
+			}
+		}
+
+		unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// lock serializes the version check and the update of a single message, so that an older change cannot
+// overwrite a newer one, which is checked concurrently.
+func (r *Replicator) lock(e entry) (unlock func()) {
+	r.mutex.Lock()
+	m, ok := r.locks[e]
+	if !ok {
+		m = &sync.Mutex{}
+		r.locks[e] = m
+	}
+	r.mutex.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
+
+// newer advances the clock and returns true, if the change supersedes the known version. The change is not
+// recorded, because a failed change must be applicable again, e.g. after its key has been declared.
+func (r *Replicator) newer(c Change) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clock = max(r.clock, c.Version.Clock)
+
+	known, ok := r.latest[entry{tag: c.Tag, key: c.Key}]
+	return !ok || c.Version.After(known.Version)
+}
+
+// record remembers the applied change as the known version of its message.
+func (r *Replicator) record(c Change) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.latest[entry{tag: c.Tag, key: c.Key}] = c
+}
+
+func (r *Replicator) apply(c Change) error {
+	bnd, _ := r.res.AddLanguage(c.Tag)
+
+	opts := []i18n.UpdateOption{i18n.UpdateMetadata(MetadataOrigin, c.Version.Node)}
+	if c.Author != "" {
+		opts = append(opts, i18n.UpdateAuthor(c.Author))
+	}
+
+	if !c.Message.Valid() {
+		return bnd.Delete(c.Key, opts...)
+	}
+
+	return bnd.Update(c.Message, opts...)
+}
+
+// onEvent publishes local message changes. Changes applied by [Replicator.Apply] are ignored.
+func (r *Replicator) onEvent(evt i18n.Event) {
+	if evt.Kind != i18n.EventMessageUpdated {
+		return
+	}
+
+	if _, remote := evt.Metadata[MetadataOrigin]; remote {
+		return
+	}
+
+	unlock := r.lock(entry{tag: evt.Tag, key: evt.Key})
+	r.mutex.Lock()
+	r.clock++
+	c := Change{
+		Tag:     evt.Tag,
+		Key:     evt.Key,
+		Message: evt.New,
+		Author:  evt.Author,
+		Version: Version{Clock: r.clock, Node: r.opts.Node},
+	}
+
+	r.latest[entry{tag: c.Tag, key: c.Key}] = c
+	r.mutex.Unlock()
+
+	// a remote change may have been applied between the local update and this event, but the local change
+	// has the newer version and must win locally as well
+	if bnd, ok := r.res.Bundle(c.Tag); ok && !sameMessage(bnd.MessageByKey(c.Key), c.Message) {
+		if err := r.apply(c); err != nil {
+			r.fail(fmt.Errorf("cannot restore local change of %v [%v]: %w", c.Key, c.Tag, err))
+		}
+	}
+
+	unlock()
+
+	if err := r.transport.Publish(ChangeSet{Node: r.opts.Node, Changes: []Change{c}}); err != nil {
+		r.fail(fmt.Errorf("cannot publish change of %v [%v]: %w", c.Key, c.Tag, err))
+	}
+}
+
+func sameMessage(a, b i18n.Message) bool {
+	if !a.Valid() || !b.Valid() {
+		return a.Valid() == b.Valid()
+	}
+
+	return a.Kind == b.Kind && a.Value == b.Value && a.Quantities == b.Quantities
+}
+
+func (r *Replicator) fail(err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(err)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package replication_test
+
+import (
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/i18n/replication"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func newReplica(t *testing.T, network *replication.Loopback, node string) (*i18n.Resources, i18n.StrHnd, *replication.Replicator) {
+	t.Helper()
+
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	res.Flush()
+
+	rep, err := replication.New(&res, network.Transport(), replication.Options{Node: node, OnError: func(err error) {
+		t.Error(err)
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(rep.Close)
+	return &res, hello, rep
+}
+
+func TestReplicator(t *testing.T) {
+	network := replication.NewLoopback()
+	a, hello, repA := newReplica(t, network, "a")
+	b, _, _ := newReplica(t, network, "b")
+
+	if err := a.MustMatchBundle(language.German).Update(i18n.Message{Key: "app.hello", Value: "Moin"}, i18n.UpdateAuthor("alice")); err != nil {
+		t.Fatal(err)
+	}
+
+	b.Flush()
+	deB := b.MustMatchBundle(language.German)
+	if v := deB.Resolve(hello.String()); v != "Moin" {
+		t.Fatal(v)
+	}
+
+	revs, err := b.History(language.German, "app.hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revs) != 1 || revs[0].Author != "alice" || revs[0].Metadata[replication.MetadataOrigin] != "a" {
+		t.Fatalf("unexpected history: %+v", revs)
+	}
+
+	stale := repA.Changes()
+	if err := deB.Update(i18n.Message{Key: "app.hello", Value: "Servus"}); err != nil {
+		t.Fatal(err)
+	}
+
+	a.Flush()
+	if v := a.MustMatchBundle(language.German).Resolve("app.hello"); v != "Servus" {
+		t.Fatal(v)
+	}
+
+	// replaying an outdated change set has no effect
+	if err := repA.Apply(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	a.Flush()
+	if v := a.MustMatchBundle(language.German).Resolve("app.hello"); v != "Servus" {
+		t.Fatalf("stale change must not win: %s", v)
+	}
+}
+
+func TestReplicator_Delete(t *testing.T) {
+	network := replication.NewLoopback()
+	a, _, _ := newReplica(t, network, "a")
+	b, _, _ := newReplica(t, network, "b")
+
+	if err := a.MustMatchBundle(language.German).Delete("app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	b.Flush()
+	if v := b.MustMatchBundle(language.German).Resolve("app.hello"); v != "hello" {
+		t.Fatalf("expected fallback after delete: %s", v)
+	}
+}
+
+func TestReplicator_ConcurrentRemoteChange(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	res.Flush()
+
+	// apply an older remote change right after the local update, but before the replicator has seen it
+	var rep *replication.Replicator
+	interfered := false
+	res.Subscribe(func(evt i18n.Event) {
+		if evt.Kind != i18n.EventMessageUpdated || interfered {
+			return
+		}
+
+		interfered = true
+		remote := replication.Change{
+			Tag:     language.German,
+			Key:     "app.hello",
+			Message: i18n.Message{Key: "app.hello", Kind: i18n.MessageString, Value: "remote"},
+			Version: replication.Version{Clock: 5, Node: "b"},
+		}
+
+		if err := rep.Apply(replication.ChangeSet{Node: "b", Changes: []replication.Change{remote}}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rep = option.Must(replication.New(&res, replication.NewLoopback().Transport(), replication.Options{Node: "a"}))
+	defer rep.Close()
+
+	de := res.MustMatchBundle(language.German)
+	if err := de.Update(i18n.Message{Key: "app.hello", Value: "local"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the local change has the newer version and must win everywhere
+	cs := rep.Changes()
+	if len(cs.Changes) != 1 || cs.Changes[0].Message.Value != "local" || cs.Changes[0].Version.Clock != 6 {
+		t.Fatalf("unexpected changes: %+v", cs)
+	}
+
+	if v := de.MessageByKey("app.hello"); v.Value != "local" {
+		t.Fatal(v.Value)
+	}
+}
+
+func TestReplicator_RetryFailedChange(t *testing.T) {
+	var res i18n.Resources
+	res.AddLanguage(language.German)
+	rep := option.Must(replication.New(&res, replication.NewLoopback().Transport(), replication.Options{Node: "a"}))
+	defer rep.Close()
+
+	cs := replication.ChangeSet{Node: "b", Changes: []replication.Change{{
+		Tag:     language.German,
+		Key:     "app.later",
+		Message: i18n.Message{Key: "app.later", Kind: i18n.MessageString, Value: "später"},
+		Version: replication.Version{Clock: 1, Node: "b"},
+	}}}
+
+	if err := rep.Apply(cs); err == nil {
+		t.Fatal("expected error for an undeclared key")
+	}
+
+	if v := rep.Changes(); len(v.Changes) != 0 {
+		t.Fatalf("a failed change must not be recorded: %+v", v)
+	}
+
+	option.Must(res.AddString("app.later", i18n.Values{language.English: "later"}))
+	if err := rep.Apply(cs); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := res.MustMatchBundle(language.German).MessageByKey("app.later"); v.Value != "später" {
+		t.Fatal(v.Value)
+	}
+
+	if v := rep.Changes(); len(v.Changes) != 1 {
+		t.Fatalf("expected the applied change: %+v", v)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import "golang.org/x/text/language"
+
+// resolvedTable contains the effective message of each handle slot after applying the regional inheritance and
+// the fallback rules, so that a fallback costs the same as a direct hit. The messages refer to the immutable
+// flushed slices of the providing bundles, thus a nil entry means that no bundle provides the message. It is built
+// by [Resources.Flush] and only valid as long as the generation of the parent has not changed.
+type resolvedTable struct {
+	generation uint64
+	data       []*strData
+	origin     []*Bundle
+}
+
+// lookupSlot returns the own or inherited message of the slot together with the providing bundle.
+func (b *Bundle) lookupSlot(s int) (strData, *Bundle, bool) {
+	if data, ok := b.strings.At(s); ok && data.kind != MessageUndefined {
+		return data, b, true
+	}
+
+	if base := b.inherits.Load(); base != nil {
+		return base.lookupSlot(s)
+	}
+
+	return strData{}, nil, false
+}
+
+// resolveSlot applies the fallback rules without using the precomputed table.
+func (b *Bundle) resolveSlot(s int) (strData, *Bundle, bool) {
+	if data, origin, ok := b.lookupSlot(s); ok {
+		return data, origin, true
+	}
+
+	for fb := range b.parent.fallbackBundles(b.tag, b.tag) {
+		if data, origin, ok := fb.lookupSlot(s); ok {
+			return data, origin, true
+		}
+	}
+
+	return strData{}, nil, false
+}
+
+// buildResolved precomputes the resolved table for the given generation of the parent from the flushed
+// messages. The caller must hold the mutex of the parent.
+func (b *Bundle) buildResolved(generation uint64, slots int) {
+	// the lookup order is the same for each slot, see [Bundle.resolveSlot]
+	var order []*Bundle
+	var views []*[]strData
+	seen := map[*Bundle]bool{}
+	add := func(bnd *Bundle) {
+		for ; bnd != nil && !seen[bnd]; bnd = bnd.inherits.Load() {
+			seen[bnd] = true
+			if v := bnd.strings.readSlice.Load(); v != nil {
+				order = append(order, bnd)
+				views = append(views, v)
+			}
+		}
+	}
+
+	add(b)
+	for fb := range b.parent.fallbackBundles(b.tag, b.tag) {
+		add(fb)
+	}
+
+	t := &resolvedTable{
+		generation: generation,
+		data:       make([]*strData, slots),
+		origin:     make([]*Bundle, slots),
+	}
+
+	for s := range slots {
+		for i, v := range views {
+			if s < len(*v) && (*v)[s].kind != MessageUndefined {
+				t.data[s], t.origin[s] = &(*v)[s], order[i]
+				break
+			}
+		}
+	}
+
+	b.resolved.Store(t)
+}
+
+// resolvedAt returns the effective message of the handle from the precomputed table. It returns false if the
+// table is missing or outdated or if it does not provide the message, and the caller must resolve the message the
+// slow way.
+func (b *Bundle) resolvedAt(hnd int32) (strData, bool) {
+	t := b.resolved.Load()
+	if t == nil || t.generation != b.parent.generation.Load() || !b.parent.owns(hnd) {
+		return strData{}, false
+	}
+
+	if s := slot(hnd); s < len(t.data) && t.data[s] != nil {
+		return *t.data[s], true
+	}
+
+	return strData{}, false
+}
+
+// Origin returns the bundle which effectively provides the message of the given key for this bundle, after
+// applying the regional inheritance (see [Resources.AddRegion]) and the fallback rules (see
+// [Resources.SetFallbacks]). The message is a fallback, if the returned bundle is not this bundle.
+// Returns false if no bundle contains the message at all. Overlays are not taken into account.
+func (b *Bundle) Origin(key Key) (*Bundle, bool) {
+	hnd, ok := b.parent.reverseHandles.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if t := b.resolved.Load(); t != nil && t.generation == b.parent.generation.Load() {
+		if s := slot(hnd); s < len(t.origin) && t.origin[s] != nil {
+			return t.origin[s], true
+		}
+	}
+
+	_, origin, ok := b.resolveSlot(slot(hnd))
+	return origin, ok
+}
+
+// lookupOrigin returns the effective message of the handle and the bundle which provides it, taking overlays,
+// the regional inheritance and the fallback rules into account.
+func (b *Bundle) lookupOrigin(hnd int32) (strData, *Bundle, bool) {
+	if !b.parent.owns(hnd) {
+		return strData{}, nil, false
+	}
+
+	if b.base != nil {
+		if data, ok := b.strings.At(slot(hnd)); ok && data.kind != MessageUndefined {
+			return data, b, true
+		}
+
+		return b.base.lookupOrigin(hnd)
+	}
+
+	if t := b.resolved.Load(); t != nil && t.generation == b.parent.generation.Load() {
+		if s := slot(hnd); s < len(t.data) && t.data[s] != nil {
+			return *t.data[s], t.origin[s], true
+		}
+	}
+
+	return b.resolveSlot(slot(hnd))
+}
+
+// StringWithTag is like [Bundle.String] but also returns the language which actually provided the text, which
+// differs from [Bundle.Tag] if a fallback language has been used, e.g. for the HTML lang attribute.
+func (b *Bundle) StringWithTag(id StrHnd) (string, language.Tag, bool) {
+	data, origin, ok := b.lookupOrigin(int32(id))
+	if !ok || data.kind != MessageString {
+		return "", language.Und, false
+	}
+
+	return data.constStr, origin.tag, true
+}
+
+// VarStringWithTag is like [Bundle.VarString] but also returns the language which actually provided the text.
+// See also [Bundle.StringWithTag].
+func (b *Bundle) VarStringWithTag(id VarStrHnd, args ...Attr) (string, language.Tag, bool) {
+	data, origin, ok := b.lookupOrigin(int32(id))
+	if !ok || data.kind != MessageVarString {
+		return "", language.Und, false
+	}
+
+	return data.template.Execute(args...), origin.tag, true
+}
+
+// QuantityStringWithTag is like [Bundle.QuantityString] but also returns the language which actually provided
+// the text. In contrast to [Bundle.QuantityString], the plural rules of the providing language are applied.
+// See also [Bundle.StringWithTag].
+func (b *Bundle) QuantityStringWithTag(id QStrHnd, quantity float64, args ...Attr) (string, language.Tag, bool) {
+	data, origin, ok := b.lookupOrigin(int32(id))
+	if !ok || data.kind != MessageQuantities {
+		return "", language.Und, false
+	}
+
+	return data.quantityTemplates.execute(origin.tag, quantity, args...), origin.tag, true
+}
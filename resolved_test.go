@@ -0,0 +1,102 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestBundle_Origin(t *testing.T) {
+	var res i18n.Resources
+	res.SetPriorities(language.English)
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	bye := option.Must(res.AddString("app.bye", i18n.Values{language.English: "bye"}))
+	res.Flush()
+
+	de := res.MustMatchBundle(language.German)
+	en := res.MustMatchBundle(language.English)
+
+	slowReads := res.Stats().SlowReads
+	if v := bye.Get(de); v != "bye" {
+		t.Fatal(v)
+	}
+
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	if res.Stats().SlowReads != slowReads {
+		t.Fatal("expected precomputed fallback")
+	}
+
+	if origin, ok := de.Origin("app.bye"); !ok || origin != en {
+		t.Fatal("expected english fallback", origin)
+	}
+
+	if origin, ok := de.Origin("app.hello"); !ok || origin != de {
+		t.Fatal("expected no fallback", origin)
+	}
+
+	// outdated tables are not used
+	if err := de.Update(i18n.Message{Key: "app.bye", Value: "tschüss"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := bye.Get(de); v != "tschüss" {
+		t.Fatal(v)
+	}
+
+	if origin, ok := de.Origin("app.bye"); !ok || origin != de {
+		t.Fatal("expected no fallback", origin)
+	}
+}
+
+func TestBundle_StringWithTag(t *testing.T) {
+	var res i18n.Resources
+	res.SetPriorities(language.English)
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	greet := option.Must(res.AddVarString("app.greet", i18n.Values{language.English: "hi {name}"}))
+	apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{language.English: {One: "one apple", Other: "{n} apples"}}))
+
+	for _, flush := range []bool{false, true} {
+		if flush {
+			res.Flush()
+		}
+
+		de := res.MustMatchBundle(language.German)
+		if v, tag, ok := de.StringWithTag(hello); !ok || v != "hallo" || tag != language.German {
+			t.Fatal(v, tag)
+		}
+
+		if v, tag, ok := de.VarStringWithTag(greet, i18n.String("name", "Anna")); !ok || v != "hi Anna" || tag != language.English {
+			t.Fatal(v, tag)
+		}
+
+		if v, tag, ok := de.QuantityStringWithTag(apples, 1, i18n.Int("n", 1)); !ok || v != "one apple" || tag != language.English {
+			t.Fatal(v, tag)
+		}
+
+		if _, _, ok := de.StringWithTag(i18n.StrHnd(greet)); ok {
+			t.Fatal("expected type mismatch")
+		}
+	}
+
+	tenant := res.NewOverlay()
+	de, _ := tenant.MatchBundle(language.German)
+	if err := de.Update(i18n.Message{Key: "app.greet", Value: "moin {name}"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, tag, ok := de.VarStringWithTag(greet, i18n.String("name", "Anna")); !ok || v != "moin Anna" || tag != language.German {
+		t.Fatal(v, tag)
+	}
+}
@@ -63,29 +63,81 @@ func LocalizationVarHint(name string, description string) Option {
 }
 
 type VarHint struct {
-	Name        string
-	Description string
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 // Resources contains the finally compiled and validated resources and also any pending and not yet flushed changes.
 // This allows Bundle instances to behave as singletons in the context of their Resources parent and makes their usage
-// easier.
+// easier. A single instance holds at most 1,048,575 keys, see [ErrTooManyKeys], and at most 2048 instances with
+// keys, not counting clones, may be alive at the same time, see [ErrTooManyInstances], because each handle encodes
+// its owning instance.
 type Resources struct {
 	children        bufferedMap[language.Tag, *Bundle]
-	lastHandle      atomic.Int32
+	lastHandle      atomic.Int32 // last allocated slot, see [Resources.handle]
+	instanceID      atomic.Int32
 	handles         bufferedMap[int32, Key]
 	reverseHandles  bufferedMap[Key, int32]
 	keyDescriptions bufferedMap[Key, string]
+	kinds           bufferedMap[Key, MessageType]
+	deprecations    bufferedMap[Key, Key]
+	aliases         bufferedMap[Key, Key]
+	stableHandles   bufferedMap[uint64, int32]
+	namespaces      bufferedMap[string, struct{}]
 	varHints        map[Key][]VarHint
 	matcher         atomic.Pointer[language.Matcher]
 	priorities      bufferedSlice[language.Tag]
+	fallbacks       bufferedMap[language.Tag, []language.Tag]
+	store           Store
+	revisions       RevisionLog
+	events          eventBus
+	flushes         flushState
+	generation      atomic.Uint64 // incremented by each mutation, see [resolvedTable]
+	hash            atomic.Pointer[contentHash]
 	mutex           sync.Mutex
 }
 
-func (r *Resources) nextHnd() int32 {
-	h := r.lastHandle.Add(1)
+// register allocates a new handle for the given key. The caller must hold the mutex.
+func (r *Resources) register(key Key, kind MessageType) (int32, error) {
+	hnd, err := r.nextHnd()
+	if err != nil {
+		return 0, fmt.Errorf("cannot add %v: %w", key, err)
+	}
+
+	r.handles.Put(hnd, key)
+	r.reverseHandles.Put(key, hnd)
+	r.kinds.Put(key, kind)
+	r.registerStable(key, hnd, false)
+	r.emit(Event{Kind: EventKeyAdded, Key: key})
+	return hnd, nil
+}
+
+// addBundle creates and registers a new empty bundle. The caller must hold the mutex.
+func (r *Resources) addBundle(tag language.Tag) *Bundle {
+	bnd := newBundle(r, tag)
+	r.putBundle(bnd)
+	return bnd
+}
+
+func (r *Resources) putBundle(bnd *Bundle) {
+	r.clearMatcher()
+	r.children.Put(bnd.tag, bnd)
+	r.emit(Event{Kind: EventLanguageAdded, Tag: bnd.tag})
+}
+
+// nextHnd allocates the next slot. The caller must hold the mutex.
+func (r *Resources) nextHnd() (int32, error) {
+	if r.lastHandle.Load() >= hndSlotMask {
+		return 0, ErrTooManyKeys
+	}
+
+	if _, err := r.registerInstance(); err != nil {
+		return 0, err
+	}
+
+	h := r.handle(int(r.lastHandle.Add(1)))
 	strHndTable.Put(h, formatStrHnd(h))
-	return h
+	return h, nil
 }
 
 func (r *Resources) VarHints(key Key) iter.Seq[VarHint] {
@@ -97,10 +149,42 @@ func (r *Resources) VarHints(key Key) iter.Seq[VarHint] {
 }
 
 func (r *Resources) Hint(key Key) string {
-	v, _ := r.keyDescriptions.Get(key)
+	v, _ := r.keyDescriptions.Get(r.canonicalKey(key))
 	return v
 }
 
+// SetHint replaces the description of the key, which has been declared by [LocalizationHint].
+func (r *Resources) SetHint(key Key, hint string) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.reverseHandles.Get(key); !ok {
+		return fmt.Errorf("key has no associated string handle: %v", key)
+	}
+
+	r.keyDescriptions.Put(r.canonicalKey(key), hint)
+	r.mutated()
+	return nil
+}
+
+// SetVarHints replaces all variable descriptions of the key, which have been declared by [LocalizationVarHint].
+func (r *Resources) SetVarHints(key Key, hints []VarHint) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	if _, ok := r.reverseHandles.Get(key); !ok {
+		return fmt.Errorf("key has no associated string handle: %v", key)
+	}
+
+	if r.varHints == nil {
+		r.varHints = map[Key][]VarHint{}
+	}
+
+	r.varHints[r.canonicalKey(key)] = slices.Clone(hints)
+	r.mutated()
+	return nil
+}
+
 // SetPriorities updates the matching fallback priority of the given language.
 // The lowest priority is the last fallback. The higher the priority, the more specific it becomes in the matching
 // order. As default, the first tag is the last resort fallback.
@@ -108,6 +192,7 @@ func (r *Resources) SetPriorities(tags ...language.Tag) {
 	r.priorities.Clear()
 	r.priorities.Append(tags...)
 	r.matcher.Store(nil)
+	r.publish(Event{Kind: EventPrioritiesChanged})
 }
 
 // AddString either adds the given string key or returns os.ErrExist and the handle of the key.
@@ -115,24 +200,24 @@ func (r *Resources) SetPriorities(tags ...language.Tag) {
 func (r *Resources) AddString(key Key, values Values, opts ...Option) (StrHnd, error) {
 	// every field is already race-free, but we need to protect our logical invariants
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	defer r.unlock()
 
 	if v, ok := r.reverseHandles.Get(key); ok {
 		return StrHnd(v), os.ErrExist
 	}
 
-	hnd := r.nextHnd()
-	r.handles.Put(hnd, key)
-	r.reverseHandles.Put(key, hnd)
+	hnd, err := r.register(key, MessageString)
+	if err != nil {
+		return 0, err
+	}
+
 	for tag, str := range values {
 		bnd, ok := r.children.Get(tag)
 		if !ok {
-			bnd = newBundle(r, tag)
-			r.clearMatcher()
-			r.children.Put(tag, bnd)
+			bnd = r.addBundle(tag)
 		}
 
-		bnd.strings.Set(int(hnd), strData{
+		bnd.strings.Set(slot(hnd), strData{
 			kind:     MessageString,
 			constStr: str,
 		})
@@ -148,41 +233,92 @@ func (r *Resources) AddString(key Key, values Values, opts ...Option) (StrHnd, e
 // AddLanguage ensures that at least an empty bundle with the given language is matchable.
 func (r *Resources) AddLanguage(tag language.Tag) (*Bundle, bool) {
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	defer r.unlock()
 
 	bnd, ok := r.children.Get(tag)
 	if ok {
 		return bnd, false
 	}
 
-	bnd = newBundle(r, tag)
 	r.priorities.Append(tag)
-	r.clearMatcher()
-	r.children.Put(tag, bnd)
+	bnd = r.addBundle(tag)
 
 	return bnd, true
 }
 
+// RemoveLanguage removes the bundle of the given language together with its priority and its fallback chain.
+// Messages of the language which are persisted in an attached [Store] are deleted as well, so that the language
+// does not come back after a restart. Already obtained bundles stay readable, but they are not matched anymore.
+// The base language of a region cannot be removed, see [Resources.AddRegion]. Returns [os.ErrNotExist] if the
+// language has not been added.
+func (r *Resources) RemoveLanguage(tag language.Tag) error {
+	r.mutex.Lock()
+	defer r.unlock()
+
+	bnd, ok := r.children.Get(tag)
+	if !ok {
+		return fmt.Errorf("cannot remove language %v: %w", tag, os.ErrNotExist)
+	}
+
+	for _, other := range r.children.All() {
+		if other.inherits.Load() == bnd {
+			return fmt.Errorf("cannot remove language %v: it is the base of region %v", tag, other.tag)
+		}
+	}
+
+	if r.store != nil {
+		msgs, err := r.store.All()
+		if err != nil {
+			return fmt.Errorf("cannot remove language %v: %w", tag, err)
+		}
+
+		for _, sm := range msgs {
+			if sm.Tag != tag {
+				continue
+			}
+
+			if err := r.store.Delete(tag, sm.Message.Key); err != nil {
+				return fmt.Errorf("cannot remove language %v: %w", tag, err)
+			}
+		}
+	}
+
+	var priorities []language.Tag
+	for _, t := range r.priorities.All() {
+		if t != tag {
+			priorities = append(priorities, t)
+		}
+	}
+
+	r.priorities.Replace(priorities)
+	r.fallbacks.Delete(tag)
+	r.children.Delete(tag)
+	r.clearMatcher()
+	r.emit(Event{Kind: EventLanguageRemoved, Tag: tag})
+
+	return nil
+}
+
 // AddVarString either adds the given string key or returns os.ErrExist and the handle of the key.
 // Use [Resources.Flush] after mutation to fixate the returned handles and remove any mutex locks for read accesses.
 func (r *Resources) AddVarString(key Key, values Values, opts ...Option) (VarStrHnd, error) {
 	// every field is already race-free, but we need to protect our logical invariants
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	defer r.unlock()
 
 	if v, ok := r.reverseHandles.Get(key); ok {
 		return VarStrHnd(v), os.ErrExist
 	}
 
-	hnd := r.nextHnd()
-	r.handles.Put(hnd, key)
-	r.reverseHandles.Put(key, hnd)
+	hnd, err := r.register(key, MessageVarString)
+	if err != nil {
+		return 0, err
+	}
+
 	for tag, str := range values {
 		bnd, ok := r.children.Get(tag)
 		if !ok {
-			bnd = newBundle(r, tag)
-			r.clearMatcher()
-			r.children.Put(tag, bnd)
+			bnd = r.addBundle(tag)
 		}
 
 		tpl, err := ParseTemplate(str)
@@ -190,7 +326,7 @@ func (r *Resources) AddVarString(key Key, values Values, opts ...Option) (VarStr
 			return 0, err
 		}
 
-		bnd.strings.Set(int(hnd), strData{
+		bnd.strings.Set(slot(hnd), strData{
 			kind:     MessageVarString,
 			template: tpl,
 		})
@@ -208,21 +344,21 @@ func (r *Resources) AddVarString(key Key, values Values, opts ...Option) (VarStr
 func (r *Resources) AddQuantityString(key Key, values QValues, opts ...Option) (QStrHnd, error) {
 	// every field is already race-free, but we need to protect our logical invariants
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	defer r.unlock()
 
 	if v, ok := r.reverseHandles.Get(key); ok {
 		return QStrHnd(v), os.ErrExist
 	}
 
-	hnd := r.nextHnd()
-	r.handles.Put(hnd, key)
-	r.reverseHandles.Put(key, hnd)
+	hnd, err := r.register(key, MessageQuantities)
+	if err != nil {
+		return 0, err
+	}
+
 	for tag, quants := range values {
 		bnd, ok := r.children.Get(tag)
 		if !ok {
-			bnd = newBundle(r, tag)
-			r.clearMatcher()
-			r.children.Put(tag, bnd)
+			bnd = r.addBundle(tag)
 		}
 
 		qtpls, err := parseQuantityTemplates(quants)
@@ -230,7 +366,7 @@ func (r *Resources) AddQuantityString(key Key, values QValues, opts ...Option) (
 			return 0, err
 		}
 
-		bnd.strings.Set(int(hnd), strData{
+		bnd.strings.Set(slot(hnd), strData{
 			kind:              MessageQuantities,
 			quantityTemplates: qtpls,
 		})
@@ -252,15 +388,36 @@ func (r *Resources) clearMatcher() {
 // which requires no mutex locks for read access. Note that any mutation will remove the lock-free access until
 // the Resources are flushed again.
 func (r *Resources) Flush() {
-	r.children.Flush()
+	r.mutex.Lock()
+	r.flush()
+	r.unlock()
+
+	r.Hash()
+}
+
+// flush is like [Resources.Flush]. The caller must hold the mutex, so that concurrent flushes, e.g. by the
+// automatic flush, are serialized and cannot publish tables of a mutation which is still in progress.
+func (r *Resources) flush() {
+	// load the generation before the buffers, so that a concurrent update of a bundle, which bumps the generation
+	// after writing, either becomes part of the tables or invalidates them
+	generation := r.generation.Load()
+	for _, b := range r.buffers() {
+		b.Flush()
+	}
+
 	for _, bnd := range r.children.All() {
 		bnd.Flush()
 	}
 
-	r.handles.Flush()
-	r.reverseHandles.Flush()
-	r.priorities.Flush()
+	slots := int(r.lastHandle.Load()) + 1
+	for _, bnd := range r.children.All() {
+		bnd.buildResolved(generation, slots)
+	}
+
 	strHndTable.Flush()
+	stableHndTable.Flush()
+	r.flushed()
+	r.emit(Event{Kind: EventFlushed})
 }
 
 // StringKey either returns the handle if the key is already known or it adds a new english string with the
@@ -347,7 +504,7 @@ func (r *Resources) MatchTag(tag language.Tag) (language.Tag, bool) {
 // MatchString finds the best bundle match and resolves the localized string. If the best match does not contain
 // the string, it falls through.
 func (r *Resources) MatchString(tag language.Tag, hnd StrHnd) (string, bool) {
-	str, _ := r.matchStrData(tag, int(hnd))
+	str, _ := r.matchStrData(tag, int32(hnd))
 
 	if str.kind != MessageString {
 		return "", false
@@ -358,7 +515,7 @@ func (r *Resources) MatchString(tag language.Tag, hnd StrHnd) (string, bool) {
 
 // MatchVarString uses an internally pre-parsed template and applies the given attributes on it.
 func (r *Resources) MatchVarString(tag language.Tag, hnd VarStrHnd, args ...Attr) (string, bool) {
-	str, _ := r.matchStrData(tag, int(hnd))
+	str, _ := r.matchStrData(tag, int32(hnd))
 
 	if str.kind != MessageVarString {
 		return "", false
@@ -368,7 +525,7 @@ func (r *Resources) MatchVarString(tag language.Tag, hnd VarStrHnd, args ...Attr
 }
 
 func (r *Resources) MatchQuantityString(tag language.Tag, hnd QStrHnd, quantity float64, args ...Attr) (string, bool) {
-	str, _ := r.matchStrData(tag, int(hnd))
+	str, _ := r.matchStrData(tag, int32(hnd))
 
 	if str.kind != MessageQuantities {
 		return "", false
@@ -378,30 +535,30 @@ func (r *Resources) MatchQuantityString(tag language.Tag, hnd QStrHnd, quantity
 
 }
 
-func (r *Resources) matchStrData(tag language.Tag, hnd int) (strData, bool) {
+func (r *Resources) matchStrData(tag language.Tag, hnd int32) (strData, bool) {
+	if !r.owns(hnd) {
+		return strData{}, false
+	}
+
 	b, ok := r.MatchBundle(tag)
 	if !ok {
 		return strData{}, false
 	}
 
-	if str, ok := b.strings.At(hnd); ok && str.kind != MessageUndefined {
-		return str, true
+	if b.tag == tag {
+		if str, ok := b.resolvedAt(hnd); ok {
+			return str, str.kind != MessageUndefined
+		}
 	}
 
-	// walk over in priorities to fall through in order
-	for _, tag := range r.priorities.All() {
-		if b, ok := r.children.Get(tag); ok {
-			if str, ok := b.strings.At(hnd); ok && str.kind != MessageUndefined {
-				return str, true
-			}
-		}
+	if str, ok := b.at(hnd); ok && str.kind != MessageUndefined {
+		return str, true
 	}
 
-	// well, perhaps priorities do not match children, try again picking something.
 	// this may be highly redundant, perhaps there is no such string at all, but there must be at least one,
 	// otherwise we could not get that entry.
-	for _, b := range r.children.All() {
-		if str, ok := b.strings.At(hnd); ok && str.kind != MessageUndefined {
+	for b := range r.fallbackBundles(tag, b.tag) {
+		if str, ok := b.at(hnd); ok && str.kind != MessageUndefined {
 			return str, true
 		}
 	}
@@ -440,6 +597,11 @@ func (r *Resources) Bundle(tag language.Tag) (*Bundle, bool) {
 // MessageType returns the expected type for the given key. If the key is declared at least in a single bundle,
 // it will return the configured type. Otherwise, returns MessageUndefined.
 func (r *Resources) MessageType(key Key) MessageType {
+	key = r.canonicalKey(key)
+	if t, ok := r.kinds.Get(key); ok {
+		return t
+	}
+
 	for _, bnd := range r.children.All() {
 		if t := bnd.MessageTypeByKey(key); t != MessageUndefined {
 			return t
@@ -495,8 +657,8 @@ func (r *Resources) Tags() []language.Tag {
 }
 
 func (r *Resources) AllKeys() iter.Seq[Key] {
-	tmp := make([]Key, 0, r.reverseHandles.Len())
-	for key := range r.reverseHandles.All() {
+	tmp := make([]Key, 0, r.handles.Len())
+	for _, key := range r.handles.All() {
 		tmp = append(tmp, key)
 	}
 
@@ -506,8 +668,8 @@ func (r *Resources) AllKeys() iter.Seq[Key] {
 
 // SortedKeys returns a snapshot of all ascending sorted keys
 func (r *Resources) SortedKeys() []Key {
-	tmp := make([]Key, 0, r.reverseHandles.Len())
-	for key := range r.reverseHandles.All() {
+	tmp := make([]Key, 0, r.handles.Len())
+	for _, key := range r.handles.All() {
 		tmp = append(tmp, key)
 	}
 
@@ -532,11 +694,18 @@ func (r *Resources) Clone() *Resources {
 	defer r.mutex.Unlock()
 
 	clone := &Resources{}
+	r.shareInstance(clone)
 	r.children.CopyInto(&clone.children)
 	clone.lastHandle.Store(r.lastHandle.Load())
 	r.handles.CopyInto(&clone.handles)
 	r.reverseHandles.CopyInto(&clone.reverseHandles)
 	r.keyDescriptions.CopyInto(&clone.keyDescriptions)
+	r.kinds.CopyInto(&clone.kinds)
+	r.deprecations.CopyInto(&clone.deprecations)
+	r.aliases.CopyInto(&clone.aliases)
+	r.stableHandles.CopyInto(&clone.stableHandles)
+	r.namespaces.CopyInto(&clone.namespaces)
+	r.fallbacks.CopyInto(&clone.fallbacks)
 	clone.varHints = maps.Clone(r.varHints) // TODO potentially dangerous shallow copy
 	clone.matcher.Store(r.matcher.Load())
 	clone.priorities = *r.priorities.Clone() // TODO this copies the mutex and vet does not detect it, however there is no reference to the old mutex and the mutex-copy of clone is relevant
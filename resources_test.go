@@ -1,6 +1,7 @@
 package i18n_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/worldiety/i18n"
@@ -16,3 +17,47 @@ func TestResources_MatchTag(t *testing.T) {
 		t.Fatal(str)
 	}
 }
+
+func TestResources_RemoveLanguage(t *testing.T) {
+	store := i18n.NewMemoryStore()
+	var res i18n.Resources
+	res.SetPriorities(language.English, language.French)
+	hnd := option.Must(res.AddString("hello", i18n.Values{language.English: "hello", language.French: "bonjour", language.German: "hallo"}))
+	option.Must(res.AddRegion(language.MustParse("de-AT"), language.German))
+	if err := res.Attach(store); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.MustMatchBundle(language.French).Update(i18n.Message{Key: "hello", Value: "salut"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.RemoveLanguage(language.German); err == nil {
+		t.Fatal("the base of a region must not be removed")
+	}
+
+	if err := res.RemoveLanguage(language.French); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.RemoveLanguage(language.French); err == nil {
+		t.Fatal("expected missing language")
+	}
+
+	res.Flush()
+	if _, ok := res.Bundle(language.French); ok {
+		t.Fatal("expected removed bundle")
+	}
+
+	if v := hnd.Get(res.MustMatchBundle(language.English)); v != "hello" {
+		t.Fatal(v)
+	}
+
+	if v := slices.Collect(res.Priorities()); !slices.Equal(v, []language.Tag{language.English}) {
+		t.Fatal(v)
+	}
+
+	if msgs := option.Must(store.All()); len(msgs) != 0 {
+		t.Fatal("expected deleted store messages", msgs)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// stableTokenPrefix introduces a key hash based handle representation, see [StrHnd.StableString].
+const stableTokenPrefix = "@~"
+
+var stableHndTable = bufferedMap[int32, string]{}
+
+func keyHash(key Key) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+func formatStableToken(key Key) string {
+	return stableTokenPrefix + strconv.FormatUint(keyHash(key), 36)
+}
+
+// registerStable makes the key hash token of the key resolvable. In the extremely unlikely case of a hash
+// collision, the token keeps resolving to the first key. The caller must hold the mutex.
+func (r *Resources) registerStable(key Key, hnd int32, alias bool) {
+	h := keyHash(key)
+	if _, ok := r.stableHandles.Get(h); ok {
+		return
+	}
+
+	r.stableHandles.Put(h, hnd)
+	if !alias {
+		stableHndTable.Put(hnd, formatStableToken(key))
+	}
+}
+
+// stableHandle parses a token like @~3w5e11264sgsf and returns the associated handle.
+func (r *Resources) stableHandle(text string) (int32, bool) {
+	if !strings.HasPrefix(text, stableTokenPrefix) {
+		return 0, false
+	}
+
+	h, err := strconv.ParseUint(text[len(stableTokenPrefix):], 36, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return r.stableHandles.Get(h)
+}
+
+func stableString(hnd int32) string {
+	if v, ok := stableHndTable.Get(hnd); ok {
+		return v
+	}
+
+	// not registered through Resources, thus there is nothing stable about it
+	return formatStrHnd(hnd)
+}
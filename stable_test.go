@@ -0,0 +1,55 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestStrHnd_StableString(t *testing.T) {
+	// simulates the first build
+	var before i18n.Resources
+	hnd := option.Must(before.AddString("app.a", i18n.Values{language.English: "a"}))
+	option.Must(before.AddString("app.b", i18n.Values{language.English: "b"}))
+	token := hnd.StableString()
+	plain := hnd.String()
+
+	if !strings.HasPrefix(token, "@~") {
+		t.Fatal(token)
+	}
+
+	// simulates a rebuild with a different registration order
+	var after i18n.Resources
+	option.Must(after.AddString("app.c", i18n.Values{language.English: "c"}))
+	option.Must(after.AddString("app.b", i18n.Values{language.English: "b"}))
+	option.Must(after.AddString("app.renamed", i18n.Values{language.English: "a"}))
+	if err := after.Alias("app.a", "app.renamed"); err != nil {
+		t.Fatal(err)
+	}
+
+	after.Flush()
+
+	en := after.MustMatchBundle(language.English)
+	if v := en.Resolve(token); v != "a" {
+		t.Fatal(v)
+	}
+
+	// plain handles are bound to their instance and a rebuild would assign them differently
+	if plain == after.StringKey("app.c").String() {
+		t.Fatal("the plain handle representation is expected to be instance specific")
+	}
+
+	if v := en.Resolve("@~unknown"); v != "@~unknown" {
+		t.Fatal(v)
+	}
+}
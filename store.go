@@ -0,0 +1,333 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// StoredMessage is a [Message] together with the language it belongs to.
+type StoredMessage struct {
+	Tag     language.Tag `json:"tag"`
+	Message Message      `json:"message"`
+}
+
+// A Store persists translations which are changed at runtime, e.g. by end users through some user interface.
+// See [Resources.Attach]. Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the message for the given language and key or [os.ErrNotExist].
+	Load(tag language.Tag, key Key) (Message, error)
+
+	// Save inserts or replaces the message for the given language. A message without a kind is a tombstone,
+	// which records that the message has been removed by [Bundle.Delete].
+	Save(tag language.Tag, msg Message) error
+
+	// Delete removes the stored message or tombstone, so that the declared message applies again. Deleting
+	// a message which does not exist is not an error.
+	Delete(tag language.Tag, key Key) error
+
+	// All returns every stored message.
+	All() ([]StoredMessage, error)
+}
+
+// Attach loads all messages from the given store and applies them on top of the declared messages. Afterward,
+// every successful [Bundle.Update] is persisted into the store, so that runtime changes survive a restart.
+// Messages whose key is unknown or whose type does not match the declared type anymore are skipped and
+// reported as a joined error, however the store is attached anyway. Tombstones remove the declared message
+// again, see [Bundle.Delete]. Missing languages are added.
+// If the store also implements [RevisionLog], it is used to record the history, see [Resources.SetRevisionLog].
+func (r *Resources) Attach(store Store) error {
+	msgs, err := store.All()
+	if err != nil {
+		return fmt.Errorf("cannot load messages from store: %w", err)
+	}
+
+	// validate all rows first, so that invalid rows neither create languages nor partially apply
+	type staged struct {
+		tag  language.Tag
+		hnd  int32
+		msg  Message
+		data strData
+	}
+
+	var errs []error
+	var rows []staged
+	for _, sm := range msgs {
+		if !sm.Message.Valid() {
+			hnd, ok := r.reverseHandles.Get(sm.Message.Key)
+			if !ok {
+				errs = append(errs, fmt.Errorf("cannot apply stored tombstone [%v]: key has no associated string handle: %v", sm.Tag, sm.Message.Key))
+				continue
+			}
+
+			rows = append(rows, staged{tag: sm.Tag, hnd: hnd, msg: Message{Key: r.canonicalKey(sm.Message.Key)}})
+			continue
+		}
+
+		hnd, msg, data, err := r.prepare(sm.Message)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot apply stored message [%v]: %w", sm.Tag, err))
+			continue
+		}
+
+		rows = append(rows, staged{tag: sm.Tag, hnd: hnd, msg: msg, data: data})
+	}
+
+	r.mutex.Lock()
+	for _, row := range rows {
+		bnd, ok := r.children.Get(row.tag)
+		if !ok {
+			r.priorities.Append(row.tag)
+			bnd = r.addBundle(row.tag)
+		}
+
+		old := bnd.MessageByKey(row.msg.Key)
+		bnd.strings.Set(slot(row.hnd), row.data)
+		r.emit(Event{Kind: EventMessageUpdated, Key: row.msg.Key, Tag: row.tag, Old: old, New: row.msg})
+	}
+
+	r.store = store
+	if log, ok := store.(RevisionLog); ok {
+		r.revisions = log
+	}
+	r.unlock()
+
+	r.Flush()
+
+	return errors.Join(errs...)
+}
+
+func (r *Resources) attachedStore() Store {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.store
+}
+
+// MemoryStore is a [Store] which just keeps the messages in memory. It is useful for tests.
+type MemoryStore struct {
+	mutex    sync.Mutex
+	messages map[language.Tag]map[Key]Message
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{messages: map[language.Tag]map[Key]Message{}}
+}
+
+func (s *MemoryStore) Load(tag language.Tag, key Key) (Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msg, ok := s.messages[tag][key]
+	if !ok {
+		return Message{}, os.ErrNotExist
+	}
+
+	return msg, nil
+}
+
+func (s *MemoryStore) Save(tag language.Tag, msg Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.messages[tag] == nil {
+		s.messages[tag] = map[Key]Message{}
+	}
+
+	s.messages[tag][msg.Key] = msg
+	return nil
+}
+
+func (s *MemoryStore) Delete(tag language.Tag, key Key) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.messages[tag], key)
+	return nil
+}
+
+func (s *MemoryStore) All() ([]StoredMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var res []StoredMessage
+	for tag, msgs := range s.messages {
+		for _, msg := range msgs {
+			res = append(res, StoredMessage{Tag: tag, Message: msg})
+		}
+	}
+
+	sortStoredMessages(res)
+	return res, nil
+}
+
+// FileStore is a [Store] which keeps a JSON file per language within a directory, e.g. de.json. Each file
+// is rewritten atomically on every change, which is fine for the expected low rate of runtime translation fixes.
+// It is also a [RevisionLog] which appends to the history.jsonl file.
+type FileStore struct {
+	mutex  sync.Mutex
+	dir    string
+	lastID int64 // of the history, 0 if not yet known
+}
+
+// NewFileStore creates a store within the given directory. The directory is created on first write.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) fname(tag language.Tag) string {
+	return filepath.Join(s.dir, tag.String()+".json")
+}
+
+func (s *FileStore) read(tag language.Tag) (map[Key]Message, error) {
+	buf, err := os.ReadFile(s.fname(tag))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[Key]Message{}, nil
+		}
+
+		return nil, err
+	}
+
+	var msgs map[Key]Message
+	if err := json.Unmarshal(buf, &msgs); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", s.fname(tag), err)
+	}
+
+	if msgs == nil {
+		msgs = map[Key]Message{}
+	}
+
+	return msgs, nil
+}
+
+func (s *FileStore) write(tag language.Tag, msgs map[Key]Message) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	buf, err := json.MarshalIndent(msgs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, tag.String()+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name()) // fails after rename, which is fine
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.fname(tag))
+}
+
+func (s *FileStore) Load(tag language.Tag, key Key) (Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msgs, err := s.read(tag)
+	if err != nil {
+		return Message{}, err
+	}
+
+	msg, ok := msgs[key]
+	if !ok {
+		return Message{}, os.ErrNotExist
+	}
+
+	return msg, nil
+}
+
+func (s *FileStore) Save(tag language.Tag, msg Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msgs, err := s.read(tag)
+	if err != nil {
+		return err
+	}
+
+	msgs[msg.Key] = msg
+	return s.write(tag, msgs)
+}
+
+func (s *FileStore) Delete(tag language.Tag, key Key) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msgs, err := s.read(tag)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := msgs[key]; !ok {
+		return nil
+	}
+
+	delete(msgs, key)
+	return s.write(tag, msgs)
+}
+
+func (s *FileStore) All() ([]StoredMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var res []StoredMessage
+	for _, name := range names {
+		tag, err := language.Parse(strings.TrimSuffix(filepath.Base(name), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid store file name %s: %w", name, err)
+		}
+
+		msgs, err := s.read(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, msg := range msgs {
+			res = append(res, StoredMessage{Tag: tag, Message: msg})
+		}
+	}
+
+	sortStoredMessages(res)
+	return res, nil
+}
+
+func sortStoredMessages(msgs []StoredMessage) {
+	slices.SortFunc(msgs, func(a, b StoredMessage) int {
+		if c := strings.Compare(a.Tag.String(), b.Tag.String()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(string(a.Message.Key), string(b.Message.Key))
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_Attach(t *testing.T) {
+	stores := map[string]func() i18n.Store{
+		"memory": func() i18n.Store { return i18n.NewMemoryStore() },
+		"file":   func() i18n.Store { return i18n.NewFileStore(t.TempDir()) },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+
+			declare := func() (*i18n.Resources, i18n.StrHnd, i18n.QStrHnd) {
+				var res i18n.Resources
+				hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+				apples := option.Must(res.AddQuantityString("app.apples", i18n.QValues{language.English: {One: "one apple", Other: "apples"}}))
+				return &res, hello, apples
+			}
+
+			res, hello, apples := declare()
+			if err := res.Attach(store); err != nil {
+				t.Fatal(err)
+			}
+
+			de, _ := res.AddLanguage(language.German)
+			if err := de.Update(i18n.Message{Key: "app.hello", Value: "hallo"}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := de.Update(i18n.Message{Key: "app.apples", Kind: i18n.MessageQuantities, Quantities: i18n.Quantities{One: "Apfel", Other: "Äpfel"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			if msg := option.Must(store.Load(language.German, "app.hello")); msg.Value != "hallo" {
+				t.Fatal(msg)
+			}
+
+			if _, err := store.Load(language.German, "app.unknown"); !errors.Is(err, os.ErrNotExist) {
+				t.Fatal(err)
+			}
+
+			// simulate the next start
+			res, hello, apples = declare()
+			if err := res.Attach(store); err != nil {
+				t.Fatal(err)
+			}
+
+			de = res.MustMatchBundle(language.German)
+			if v := hello.Get(de); v != "hallo" {
+				t.Fatal(v)
+			}
+
+			if v := apples.Get(de, 2); v != "Äpfel" {
+				t.Fatal(v)
+			}
+
+			if err := store.Delete(language.German, "app.hello"); err != nil {
+				t.Fatal(err)
+			}
+
+			if msgs := option.Must(store.All()); len(msgs) != 1 {
+				t.Fatal(msgs)
+			}
+		})
+	}
+}
+
+func TestResources_AttachTombstone(t *testing.T) {
+	store := i18n.NewFileStore(t.TempDir())
+	declare := func() (*i18n.Resources, i18n.StrHnd) {
+		var res i18n.Resources
+		hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+		return &res, hello
+	}
+
+	res, _ := declare()
+	if err := res.Attach(store); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.MustMatchBundle(language.German).Delete("app.hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate the next start, the declared german message must stay deleted
+	res, hello := declare()
+	if err := res.Attach(store); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.German)); v != "hello" {
+		t.Fatal(v)
+	}
+}
+
+func TestResources_AttachInvalid(t *testing.T) {
+	store := i18n.NewMemoryStore()
+	if err := store.Save(language.French, i18n.Message{Key: "app.unknown", Value: "inconnu"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	if err := res.Attach(store); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+
+	// an invalid row must not create its language
+	if _, ok := res.Bundle(language.French); ok {
+		t.Fatal("unexpected language")
+	}
+}
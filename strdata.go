@@ -18,18 +18,84 @@ const (
 	MessageQuantities
 )
 
+// MessageStatus describes the review state of a message.
+type MessageStatus int8
+
+const (
+	// StatusUndefined is the status of all messages which have been declared by code or were not reviewed at all.
+	StatusUndefined MessageStatus = iota
+	// StatusMachineTranslated marks a message which has been created by a tool and has not been reviewed yet.
+	StatusMachineTranslated
+	// StatusDraft marks a message which has been proposed but is not ready for review yet.
+	StatusDraft
+	// StatusNeedsReview marks a proposed message which waits for approval.
+	StatusNeedsReview
+	// StatusApproved marks a reviewed message.
+	StatusApproved
+)
+
 type strData struct {
 	kind              MessageType
+	status            MessageStatus
 	constStr          string
 	template          Template
 	quantityTemplates quantityTemplates
 }
 
+// newStrData validates and pre-compiles the given message into its internal representation.
+func newStrData(msg Message) (strData, error) {
+	data := strData{kind: msg.Kind, status: msg.Status}
+	switch msg.Kind {
+	case MessageString:
+		data.constStr = msg.Value
+	case MessageVarString:
+		tpl, err := ParseTemplate(msg.Value)
+		if err != nil {
+			return strData{}, fmt.Errorf("failed to parse template for %v: %w", msg.Key, err)
+		}
+
+		data.template = tpl
+	case MessageQuantities:
+		qtpls, err := parseQuantityTemplates(msg.Quantities)
+		if err != nil {
+			return strData{}, fmt.Errorf("failed to parse quantities for %v: %w", msg.Key, err)
+		}
+
+		data.quantityTemplates = qtpls
+	default:
+		return strData{}, fmt.Errorf("unsupported message type %v", msg.Kind)
+	}
+
+	return data, nil
+}
+
+// message converts the data back into its raw and unparsed representation.
+func (d strData) message(key Key) Message {
+	var val string
+	switch d.kind {
+	case MessageString:
+		val = d.constStr
+	case MessageVarString:
+		val = d.template.raw
+	default:
+		// plurals
+	}
+
+	return Message{
+		Key:        key,
+		Kind:       d.kind,
+		Value:      val,
+		Quantities: d.quantityTemplates.raw,
+		Status:     d.status,
+	}
+}
+
 type Message struct {
-	Key        Key         `json:"key,omitempty"`
-	Kind       MessageType `json:"kind,omitempty"`
-	Value      string      `json:"value,omitempty"`     // either a string (MessageString) or a template (MessageVarString)
-	Quantities Quantities  `json:"quantities,omitzero"` // valid if MessageQuantities
+	Key        Key           `json:"key,omitempty"`
+	Kind       MessageType   `json:"kind,omitempty"`
+	Value      string        `json:"value,omitempty"`     // either a string (MessageString) or a template (MessageVarString)
+	Quantities Quantities    `json:"quantities,omitzero"` // valid if MessageQuantities
+	Status     MessageStatus `json:"status,omitempty"`
 }
 
 func (m Message) Valid() bool {
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// contentHash caches the hash of a specific version.
+type contentHash struct {
+	version uint64
+	sum     string
+}
+
+// Version returns a counter which increases with every mutation of this instance or any of its bundles, e.g. by
+// [Resources.AddString], [Bundle.Update] or [Resources.SetPriorities]. The counter is only valid within the
+// current process, see [Resources.Hash] to compare the content of different processes.
+func (r *Resources) Version() uint64 {
+	return r.generation.Load()
+}
+
+// Hash returns a hex encoded hash of the entire content, i.e. keys, hints, priorities, fallbacks and the messages
+// of all bundles. In contrast to [Resources.Version], the hash does not depend on the registration order and
+// is equal for identical content in different processes, which makes it suitable as an HTTP ETag.
+// The hash is calculated by [Resources.Flush] or on demand, if the instance has been mutated since then.
+func (r *Resources) Hash() string {
+	version := r.generation.Load()
+	if h := r.hash.Load(); h != nil && h.version == version {
+		return h.sum
+	}
+
+	h := fnv.New128a()
+	for _, key := range r.SortedKeys() {
+		replacement, deprecated := r.Deprecation(key)
+		fmt.Fprintf(h, "k%s\x00%d\x00%s\x00%v\x00%s\x00", key, r.MessageType(key), r.Hint(key), deprecated, replacement)
+		for vh := range r.VarHints(key) {
+			fmt.Fprintf(h, "v%s\x00%s\x00", vh.Name, vh.Description)
+		}
+	}
+
+	for alias, key := range r.Aliases() {
+		fmt.Fprintf(h, "a%s\x00%s\x00", alias, key)
+	}
+
+	for tag := range r.Priorities() {
+		fmt.Fprintf(h, "p%s\x00", tag)
+	}
+
+	for _, tag := range r.Tags() {
+		bnd, _ := r.children.Get(tag)
+		fmt.Fprintf(h, "b%s\x00%v\x00%s\x00", tag, r.Fallbacks(tag), bnd.Hash())
+		if base := bnd.Inherits(); base != nil {
+			fmt.Fprintf(h, "i%s\x00", base.tag)
+		}
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	r.hash.Store(&contentHash{version: version, sum: sum})
+	return sum
+}
+
+// Hash returns a hex encoded hash of the own messages of this bundle, which is equal for identical messages in
+// different processes. Inherited and fallback messages are not included, see [Resources.Hash] for that.
+// The hash is calculated by [Resources.Flush] or on demand, if the bundle has been mutated since then.
+func (b *Bundle) Hash() string {
+	version := b.Version()
+	if h := b.hash.Load(); h != nil && h.version == version {
+		return h.sum
+	}
+
+	var msgs []Message
+	for s, data := range b.strings.All() {
+		if data.kind == MessageUndefined {
+			continue
+		}
+
+		if key, ok := b.parent.handles.Get(b.parent.handle(s)); ok {
+			msgs = append(msgs, data.message(key))
+		}
+	}
+
+	slices.SortFunc(msgs, func(a, c Message) int {
+		return strings.Compare(string(a.Key), string(c.Key))
+	})
+
+	h := fnv.New128a()
+	for _, msg := range msgs {
+		hashMessage(h, msg)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	b.hash.Store(&contentHash{version: version, sum: sum})
+	return sum
+}
+
+func hashMessage(h hash.Hash, msg Message) {
+	fmt.Fprintf(h, "m%s\x00%d\x00%d\x00%s\x00", msg.Key, msg.Kind, msg.Status, msg.Value)
+	for category, value := range msg.Quantities.All() {
+		fmt.Fprintf(h, "q%s\x00%s\x00", category, value)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestResources_VersionAndHash(t *testing.T) {
+	var res i18n.Resources
+	option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello", language.German: "hallo"}))
+	option.Must(res.AddString("app.bye", i18n.Values{language.English: "bye"}))
+	res.Flush()
+
+	var other i18n.Resources
+	option.Must(other.AddString("app.bye", i18n.Values{language.English: "bye"}))
+	option.Must(other.AddString("app.hello", i18n.Values{language.German: "hallo", language.English: "hello"}))
+	other.Flush()
+
+	if res.Hash() != other.Hash() {
+		t.Fatal("expected equal hash for equal content")
+	}
+
+	de := res.MustMatchBundle(language.German)
+	version, bndVersion, hash, bndHash := res.Version(), de.Version(), res.Hash(), de.Hash()
+
+	if err := de.Update(i18n.Message{Key: "app.hello", Value: "Hallo!"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res.Flush()
+
+	if res.Version() <= version || de.Version() <= bndVersion {
+		t.Fatal("expected increased versions")
+	}
+
+	if res.Hash() == hash || de.Hash() == bndHash {
+		t.Fatal("expected changed hash")
+	}
+
+	version, hash = res.Version(), res.Hash()
+	res.SetPriorities(language.German, language.English)
+	if res.Version() <= version || res.Hash() == hash {
+		t.Fatal("expected changed version and hash after priority change")
+	}
+}
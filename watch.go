@@ -0,0 +1,203 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"slices"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// WatchOptions configures a [Watcher].
+type WatchOptions struct {
+	// Interval between two polls. Defaults to 2 seconds.
+	Interval time.Duration
+
+	// OnError is called from the polling goroutine, whenever changed files cannot be read or validated.
+	// In that case, the previous translations remain active.
+	OnError func(err error)
+
+	// OnReload is called from the polling goroutine, after the changed files have been applied and flushed.
+	OnReload func(names []string)
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchedFile remembers the applied version of a catalog file and the keys it provides.
+type watchedFile struct {
+	stamp fileStamp
+	tag   language.Tag
+	keys  []Key
+}
+
+// A Watcher polls catalog files and reloads changed files into its [Resources]. It uses no OS-specific
+// notification mechanisms and thus works with any [fs.FS], e.g. os.DirFS during development or in
+// on-premise installations.
+//
+// A key which is removed from a file, or whose file is deleted, is removed from the language of the file, unless
+// another file of that language still provides it. Like [Bundle.Delete], lookups then fall through to the other
+// languages.
+type Watcher struct {
+	res     *Resources
+	fsys    fs.FS
+	pattern string
+	opts    WatchOptions
+	files   map[string]watchedFile
+}
+
+// NewWatcher creates a Watcher for all catalog files matching the pattern. See [Resources.LoadFS] for the
+// supported file names and formats. Use [Watcher.Run] to start polling.
+func NewWatcher(res *Resources, fsys fs.FS, pattern string, opts WatchOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+
+	return &Watcher{
+		res:     res,
+		fsys:    fsys,
+		pattern: pattern,
+		opts:    opts,
+		files:   map[string]watchedFile{},
+	}
+}
+
+// Poll checks once for new, modified or deleted files and merges them. All changed files are validated first and
+// only if everything is valid, the translations are applied and published using [Resources.Flush]. Otherwise,
+// the previous translations are kept and the error is returned. Failed files are retried by the next poll,
+// together with the other changed files of the same batch. Poll returns the names of the reloaded and deleted
+// files. Poll must not be called concurrently.
+func (w *Watcher) Poll() ([]string, error) {
+	names, err := fs.Glob(w.fsys, w.pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(names)
+
+	var changed []string
+	stamps := map[string]fileStamp{}
+	for _, name := range names {
+		info, err := fs.Stat(w.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+		if old, ok := w.files[name]; ok && old.stamp == stamp {
+			continue
+		}
+
+		stamps[name] = stamp
+		changed = append(changed, name)
+	}
+
+	var deleted []string
+	for name := range w.files {
+		if _, ok := slices.BinarySearch(names, name); !ok {
+			deleted = append(deleted, name)
+		}
+	}
+
+	slices.Sort(deleted)
+
+	if len(changed) == 0 && len(deleted) == 0 {
+		return nil, nil
+	}
+
+	var catalogs []catalog
+	for _, name := range changed {
+		cat, err := readCatalog(w.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		catalogs = append(catalogs, cat)
+	}
+
+	// the files as they are after this poll
+	files := maps.Clone(w.files)
+	for _, name := range deleted {
+		delete(files, name)
+	}
+
+	for _, cat := range catalogs {
+		keys := make([]Key, 0, len(cat.values))
+		for _, v := range cat.values {
+			keys = append(keys, v.key)
+		}
+
+		files[cat.name] = watchedFile{stamp: stamps[cat.name], tag: cat.tag, keys: keys}
+	}
+
+	provided := map[language.Tag]map[Key]struct{}{}
+	for _, file := range files {
+		if provided[file.tag] == nil {
+			provided[file.tag] = map[Key]struct{}{}
+		}
+
+		for _, key := range file.keys {
+			provided[file.tag][key] = struct{}{}
+		}
+	}
+
+	// keys of the previous versions which are not provided by any file of their language anymore
+	removed := map[language.Tag][]Key{}
+	for _, name := range append(slices.Clone(changed), deleted...) {
+		old, ok := w.files[name]
+		if !ok {
+			continue
+		}
+
+		for _, key := range old.keys {
+			if _, ok := provided[old.tag][key]; ok || slices.Contains(removed[old.tag], key) {
+				continue
+			}
+
+			removed[old.tag] = append(removed[old.tag], key)
+		}
+	}
+
+	if err := w.res.mergeCatalogs(catalogs, removed); err != nil {
+		return nil, err
+	}
+
+	// only remember applied files, so that the valid files of a failed batch are not lost
+	w.files = files
+
+	return append(changed, deleted...), nil
+}
+
+// Run polls until the context is done. The first poll happens immediately and loads all files.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		names, err := w.Poll()
+		if err != nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+
+		if len(names) > 0 && w.opts.OnReload != nil {
+			w.opts.OnReload(names)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"de.json": {Data: []byte(`{"app.hello": "hallo"}`), ModTime: now},
+	}
+
+	w := i18n.NewWatcher(&res, fsys, "*.json", i18n.WatchOptions{})
+	if names := option.Must(w.Poll()); len(names) != 1 {
+		t.Fatal(names)
+	}
+
+	de := res.MustMatchBundle(language.German)
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	if names := option.Must(w.Poll()); len(names) != 0 {
+		t.Fatal("expected no change", names)
+	}
+
+	// an invalid file keeps the previous version
+	fsys["de.json"] = &fstest.MapFile{Data: []byte(`{"app.hello": {"one": "x"}}`), ModTime: now.Add(time.Second)}
+	if _, err := w.Poll(); err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	fsys["de.json"] = &fstest.MapFile{Data: []byte(`{"app.hello": "Moin"}`), ModTime: now.Add(2 * time.Second)}
+	if _, err := w.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(de); v != "Moin" {
+		t.Fatal(v)
+	}
+}
+
+func TestWatcher_PollRetriesFailedBatch(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	res.Flush()
+
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"de.json": {Data: []byte(`{"app.hello": "hallo"}`), ModTime: now},
+		"fr.json": {Data: []byte(`{"app.hello": {"one": "x"}}`), ModTime: now},
+	}
+
+	w := i18n.NewWatcher(&res, fsys, "*.json", i18n.WatchOptions{})
+	if _, err := w.Poll(); err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	// only the invalid file is fixed, the unchanged valid file must be applied anyway
+	fsys["fr.json"] = &fstest.MapFile{Data: []byte(`{"app.hello": "salut"}`), ModTime: now.Add(time.Second)}
+	if names := option.Must(w.Poll()); len(names) != 2 {
+		t.Fatal(names)
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.German)); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.French)); v != "salut" {
+		t.Fatal(v)
+	}
+}
+
+func TestWatcher_PollRemovesKeys(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	bye := option.Must(res.AddString("app.bye", i18n.Values{language.English: "bye"}))
+
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"de.json": {Data: []byte(`{"app.hello": "hallo", "app.bye": "tschüss"}`), ModTime: now},
+		"fr.json": {Data: []byte(`{"app.hello": "salut"}`), ModTime: now},
+	}
+
+	w := i18n.NewWatcher(&res, fsys, "*.json", i18n.WatchOptions{})
+	if _, err := w.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a key removed from a changed file falls through to the other languages
+	fsys["de.json"] = &fstest.MapFile{Data: []byte(`{"app.hello": "hallo"}`), ModTime: now.Add(time.Second)}
+	if _, err := w.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	de := res.MustMatchBundle(language.German)
+	if v := bye.Get(de); v != "bye" {
+		t.Fatal(v)
+	}
+
+	if v := hello.Get(de); v != "hallo" {
+		t.Fatal(v)
+	}
+
+	// the keys of a deleted file are removed as well
+	delete(fsys, "fr.json")
+	if names := option.Must(w.Poll()); len(names) != 1 || names[0] != "fr.json" {
+		t.Fatal(names)
+	}
+
+	if msg := res.MustMatchBundle(language.French).MessageByKey("app.hello"); msg.Valid() {
+		t.Fatal(msg)
+	}
+
+	if names := option.Must(w.Poll()); len(names) != 0 {
+		t.Fatal("expected no change", names)
+	}
+}
+
+func TestWatcher_PollTypeConflict(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"de.json": {Data: []byte(`{"app.hello": "hallo"}`), ModTime: now},
+	}
+
+	w := i18n.NewWatcher(&res, fsys, "*.json", i18n.WatchOptions{})
+	if _, err := w.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a plain string must not become a template, the previous version is kept
+	fsys["de.json"] = &fstest.MapFile{Data: []byte(`{"app.hello": "hallo {name}"}`), ModTime: now.Add(time.Second)}
+	if _, err := w.Poll(); err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if v := hello.Get(res.MustMatchBundle(language.German)); v != "hallo" {
+		t.Fatal(v)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Propose validates the given message and keeps it as a pending draft alongside the published value. Drafts are
+// not visible to regular lookups, see [Bundle.Preview]. If the status is undefined, [StatusDraft] is assumed.
+// A previous draft of the same key is replaced. Drafts are only kept in memory.
+func (b *Bundle) Propose(msg Message) error {
+	hnd, msg, data, err := b.prepare(msg)
+	if err != nil {
+		return err
+	}
+
+	if data.status == StatusUndefined || data.status == StatusApproved {
+		data.status = StatusDraft
+	}
+
+	b.drafts.Set(slot(hnd), data)
+	return nil
+}
+
+// Draft returns the pending draft of the given key, if any.
+func (b *Bundle) Draft(key Key) (Message, bool) {
+	hnd, ok := b.parent.reverseHandles.Get(key)
+	if !ok {
+		return Message{}, false
+	}
+
+	data, ok := b.drafts.At(slot(hnd))
+	if !ok || data.kind == MessageUndefined {
+		return Message{}, false
+	}
+
+	return data.message(key), true
+}
+
+// Drafts returns all pending drafts in ascending key order.
+func (b *Bundle) Drafts() iter.Seq[Message] {
+	var tmp []Message
+	for s, data := range b.drafts.All() {
+		if data.kind == MessageUndefined {
+			continue
+		}
+
+		if key, ok := b.parent.handles.Get(b.parent.handle(s)); ok {
+			tmp = append(tmp, data.message(key))
+		}
+	}
+
+	slices.SortFunc(tmp, func(a, c Message) int {
+		return strings.Compare(string(a.Key), string(c.Key))
+	})
+
+	return slices.Values(tmp)
+}
+
+// Approve publishes the pending draft of the given key using [Bundle.Update] with [StatusApproved] and removes
+// the draft.
+func (b *Bundle) Approve(key Key, opts ...UpdateOption) error {
+	msg, ok := b.Draft(key)
+	if !ok {
+		return fmt.Errorf("no draft to approve for key: %v", key)
+	}
+
+	msg.Status = StatusApproved
+	if err := b.Update(msg, opts...); err != nil {
+		return err
+	}
+
+	b.clearDraft(key)
+	return nil
+}
+
+// Reject discards the pending draft of the given key.
+func (b *Bundle) Reject(key Key) error {
+	if _, ok := b.Draft(key); !ok {
+		return fmt.Errorf("no draft to reject for key: %v", key)
+	}
+
+	b.clearDraft(key)
+	return nil
+}
+
+func (b *Bundle) clearDraft(key Key) {
+	if hnd, ok := b.parent.reverseHandles.Get(key); ok {
+		b.drafts.Set(slot(hnd), strData{})
+	}
+}
+
+// Preview returns a snapshot overlay (see [Bundle.Derive]) which contains all current drafts of this bundle.
+// Use it to render the pending proposals only for previewing users, e.g. reviewers.
+func (b *Bundle) Preview() *Bundle {
+	preview := b.Derive()
+	for s, data := range b.drafts.All() {
+		if data.kind != MessageUndefined {
+			preview.strings.Set(s, data)
+		}
+	}
+
+	preview.Flush()
+	return preview
+}
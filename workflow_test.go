@@ -0,0 +1,77 @@
+// Copyright (c) 2025 worldiety GmbH
+//
+// This file is part of the NAGO Low-Code Platform.
+// Licensed under the terms specified in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/worldiety/i18n"
+	"github.com/worldiety/option"
+	"golang.org/x/text/language"
+)
+
+func TestBundle_Workflow(t *testing.T) {
+	var res i18n.Resources
+	hello := option.Must(res.AddString("app.hello", i18n.Values{language.English: "hello"}))
+	bye := option.Must(res.AddString("app.bye", i18n.Values{language.English: "bye"}))
+	en := res.MustMatchBundle(language.English)
+
+	if err := en.Propose(i18n.Message{Key: "app.hello", Value: "hi", Status: i18n.StatusNeedsReview}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := en.Propose(i18n.Message{Key: "app.bye", Value: "ciao"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(en); v != "hello" {
+		t.Fatal("draft must not be published", v)
+	}
+
+	preview := en.Preview()
+	if v := hello.Get(preview); v != "hi" {
+		t.Fatal(v)
+	}
+
+	var drafts []i18n.Message
+	for msg := range en.Drafts() {
+		drafts = append(drafts, msg)
+	}
+
+	if len(drafts) != 2 || drafts[0].Key != "app.bye" || drafts[0].Status != i18n.StatusDraft || drafts[1].Status != i18n.StatusNeedsReview {
+		t.Fatal(drafts)
+	}
+
+	if err := en.Approve("app.hello", i18n.UpdateAuthor("reviewer")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := en.Reject("app.bye"); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := hello.Get(en); v != "hi" {
+		t.Fatal(v)
+	}
+
+	if v := bye.Get(en); v != "bye" {
+		t.Fatal(v)
+	}
+
+	if msg := en.MessageByKey("app.hello"); msg.Status != i18n.StatusApproved {
+		t.Fatal(msg)
+	}
+
+	if _, ok := en.Draft("app.hello"); ok {
+		t.Fatal("draft must be removed")
+	}
+
+	if err := en.Approve("app.bye"); err == nil {
+		t.Fatal("expected error")
+	}
+}